@@ -0,0 +1,29 @@
+#!/usr/bin/env bash
+# Generates the self-signed certificates used by the postgres_tls service and the TLS acceptance tests:
+#   - ca.crt: the certificate authority signing all the other certificates.
+#   - server.crt: the server certificate, only valid for `localhost` so that verify-full fails for 127.0.0.1.
+#   - client.crt: the certificate of the `tls_client` role, with an unencrypted key (client.key) and a key encrypted
+#     with the password `not_a_real_password` (client-encrypted.key).
+set -euo pipefail
+
+certs_dir="$(cd "$(dirname "$0")" && pwd)/certs"
+mkdir -p "$certs_dir"
+cd "$certs_dir"
+
+openssl req -x509 -new -nodes -newkey rsa:2048 -days 3650 \
+  -subj "/CN=terraform-provider-postgresql test CA" \
+  -keyout ca.key -out ca.crt
+
+openssl req -new -nodes -newkey rsa:2048 -subj "/CN=localhost" -keyout server.key -out server.csr
+openssl x509 -req -in server.csr -CA ca.crt -CAkey ca.key -CAcreateserial -days 3650 \
+  -extfile <(printf "subjectAltName=DNS:localhost\nextendedKeyUsage=serverAuth") -out server.crt
+
+openssl req -new -nodes -newkey rsa:2048 -subj "/CN=tls_client" -keyout client.key -out client.csr
+openssl x509 -req -in client.csr -CA ca.crt -CAkey ca.key -CAcreateserial -days 3650 \
+  -extfile <(printf "extendedKeyUsage=clientAuth") -out client.crt
+
+# Like libpq, the provider only decrypts keys using the legacy PEM encryption.
+openssl rsa -in client.key -traditional -aes256 -passout pass:not_a_real_password -out client-encrypted.key
+
+rm -f ./*.csr ./*.srl
+chmod 644 ./*.crt ./*.key
@@ -0,0 +1,2 @@
+-- Role authenticated with the client certificate whose common name is tls_client.
+CREATE ROLE tls_client LOGIN CREATEROLE;
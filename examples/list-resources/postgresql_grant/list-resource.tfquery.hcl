@@ -0,0 +1,16 @@
+# Lists the grants on the objects of the database the provider is connected to
+list "postgresql_grant" "all" {
+  provider = postgresql
+}
+
+# Lists the privileges a role holds on the tables of a schema of another database
+list "postgresql_grant" "reporting" {
+  provider = postgresql
+
+  config {
+    database    = "analytics"
+    role        = "reporting"
+    object_type = "table"
+    schema      = "sales"
+  }
+}
@@ -0,0 +1,18 @@
+# Lists the roles which can log in, along with their attributes
+list "postgresql_role" "login_roles" {
+  provider         = postgresql
+  include_resource = true
+
+  config {
+    can_login = true
+  }
+}
+
+# Lists the roles whose names start with app_
+list "postgresql_role" "app_roles" {
+  provider = postgresql
+
+  config {
+    name_pattern = "app\\_%"
+  }
+}
@@ -0,0 +1,14 @@
+# Lists the schemas of the database the provider is connected to
+list "postgresql_schema" "all" {
+  provider = postgresql
+}
+
+# Lists the schemas of another database, including the system schemas
+list "postgresql_schema" "analytics" {
+  provider = postgresql
+
+  config {
+    database               = "analytics"
+    include_system_schemas = true
+  }
+}
@@ -0,0 +1,6 @@
+import {
+  to = postgresql_database.example
+  identity = {
+    oid = 16385
+  }
+}
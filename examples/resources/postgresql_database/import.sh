@@ -0,0 +1,2 @@
+# Databases are imported using the database name.
+terraform import postgresql_database.example example_database
@@ -0,0 +1,10 @@
+resource "postgresql_database" "example" {
+  name             = "example"
+  owner            = "example_owner"
+  template         = "template0"
+  encoding         = "UTF8"
+  lc_collate       = "en_US.UTF-8"
+  lc_ctype         = "en_US.UTF-8"
+  connection_limit = 50
+  force_drop       = true
+}
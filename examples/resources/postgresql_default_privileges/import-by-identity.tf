@@ -0,0 +1,10 @@
+import {
+  to = postgresql_default_privileges.readonly_tables
+  identity = {
+    database    = "example_database"
+    owner       = "migrator"
+    role        = "readonly"
+    schema      = "app"
+    object_type = "table"
+  }
+}
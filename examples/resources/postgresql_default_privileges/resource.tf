@@ -0,0 +1,18 @@
+# Grant read access on tables created by the migrator role in the app schema
+resource "postgresql_default_privileges" "readonly_tables" {
+  database    = "example_database"
+  owner       = "migrator"
+  role        = "readonly"
+  schema      = "app"
+  object_type = "table"
+  privileges  = ["SELECT"]
+}
+
+# Revoke the built-in EXECUTE privilege of PUBLIC on functions created by the migrator role
+resource "postgresql_default_privileges" "revoke_public_execute" {
+  database    = "example_database"
+  owner       = "migrator"
+  role        = "public"
+  object_type = "function"
+  privileges  = []
+}
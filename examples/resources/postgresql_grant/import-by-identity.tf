@@ -0,0 +1,10 @@
+import {
+  to = postgresql_grant.function
+  identity = {
+    database    = "example_database"
+    role        = "app"
+    object_type = "function"
+    schema      = "public"
+    objects     = ["refresh_stats(integer)"]
+  }
+}
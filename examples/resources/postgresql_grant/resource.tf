@@ -0,0 +1,26 @@
+# Grant read access on every table of a schema
+resource "postgresql_grant" "readonly_tables" {
+  database    = "example_database"
+  role        = "readonly"
+  object_type = "table"
+  schema      = "public"
+  privileges  = ["SELECT"]
+}
+
+# Grant execute on a single function overload
+resource "postgresql_grant" "function" {
+  database    = "example_database"
+  role        = "app"
+  object_type = "function"
+  schema      = "public"
+  objects     = ["refresh_stats(integer)"]
+  privileges  = ["EXECUTE"]
+}
+
+# Grant connect on the database
+resource "postgresql_grant" "connect" {
+  database    = "example_database"
+  role        = "app"
+  object_type = "database"
+  privileges  = ["CONNECT", "TEMPORARY"]
+}
@@ -0,0 +1,7 @@
+import {
+  to = postgresql_grant_role.example
+  identity = {
+    role   = "readonly"
+    member = "example"
+  }
+}
@@ -0,0 +1,9 @@
+resource "postgresql_grant_role" "example" {
+  role         = "readonly"
+  member       = "example"
+  admin_option = false
+
+  # Requires Postgres 16 or later
+  inherit_option = true
+  set_option     = false
+}
@@ -0,0 +1,6 @@
+import {
+  to = postgresql_role.example
+  identity = {
+    oid = 16384
+  }
+}
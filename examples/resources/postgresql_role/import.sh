@@ -0,0 +1,3 @@
+# Roles are imported using the role name, or its OID prefixed with `oid:`.
+terraform import postgresql_role.example app_user
+terraform import postgresql_role.example oid:16384
@@ -4,3 +4,28 @@ resource "postgresql_role" "example" {
   connection_limit = 25
   superuser        = false
 }
+
+# With Terraform 1.11 or later, the password can be kept out of the state
+resource "postgresql_role" "app" {
+  name                = "app"
+  can_login           = true
+  password_wo         = var.app_password
+  password_wo_version = 1
+}
+
+# Configuration parameters, for all databases or for specific ones
+resource "postgresql_role" "reporting" {
+  name      = "reporting"
+  can_login = true
+
+  parameters = {
+    statement_timeout = "5min"
+    search_path       = "reporting, public"
+  }
+
+  database_parameters = {
+    analytics = {
+      work_mem = "256MB"
+    }
+  }
+}
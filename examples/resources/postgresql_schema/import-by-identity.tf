@@ -0,0 +1,7 @@
+import {
+  to = postgresql_schema.example
+  identity = {
+    database = "example_database"
+    name     = "example"
+  }
+}
@@ -0,0 +1,2 @@
+# Schemas are imported using the database name and the schema name, separated by a dot.
+terraform import postgresql_schema.example example_database.example
@@ -0,0 +1,6 @@
+resource "postgresql_schema" "example" {
+  name         = "example"
+  database     = "example_database"
+  owner        = "example_owner"
+  drop_cascade = false
+}
@@ -0,0 +1,118 @@
+package postgresql
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgservicefile"
+)
+
+// tlsEnvVars are the libpq environment variables setting the default of each TLS setting.
+var tlsEnvVars = map[string]string{
+	"sslmode":     "PGSSLMODE",
+	"sslrootcert": "PGSSLROOTCERT",
+	"sslcert":     "PGSSLCERT",
+	"sslkey":      "PGSSLKEY",
+	"sslpassword": "PGSSLPASSWORD",
+	"sslsni":      "PGSSLSNI",
+}
+
+// ConnString returns a keyword/value connection string holding settings, with the values quoted and escaped so that
+// they may contain any character.
+func ConnString(settings map[string]string) string {
+	keywords := make([]string, 0, len(settings))
+	for keyword := range settings {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+
+	pairs := make([]string, len(keywords))
+	for i, keyword := range keywords {
+		value := strings.ReplaceAll(settings[keyword], `\`, `\\`)
+		value = strings.ReplaceAll(value, `'`, `\'`)
+		pairs[i] = fmt.Sprintf("%s='%s'", keyword, value)
+	}
+
+	return strings.Join(pairs, " ")
+}
+
+// DefaultTLSSettings returns the TLS settings used when the provider configuration doesn't set them, resolved like
+// libpq does: the settings of the service named by PGSERVICE override the libpq environment variables, which override
+// the certificates found in ~/.postgresql.
+func DefaultTLSSettings() (TLSSettings, error) {
+	settings := map[string]string{}
+
+	if currentUser, err := user.Current(); err == nil {
+		sslCert := filepath.Join(currentUser.HomeDir, ".postgresql", "postgresql.crt")
+		sslKey := filepath.Join(currentUser.HomeDir, ".postgresql", "postgresql.key")
+		if fileExists(sslCert) && fileExists(sslKey) {
+			settings["sslcert"] = sslCert
+			settings["sslkey"] = sslKey
+		}
+
+		if sslRootCert := filepath.Join(currentUser.HomeDir, ".postgresql", "root.crt"); fileExists(sslRootCert) {
+			settings["sslrootcert"] = sslRootCert
+		}
+	}
+
+	for setting, envVar := range tlsEnvVars {
+		if value := os.Getenv(envVar); value != "" {
+			settings[setting] = value
+		}
+	}
+
+	if serviceName := os.Getenv("PGSERVICE"); serviceName != "" {
+		service, err := readService(serviceName)
+		if err != nil {
+			return TLSSettings{}, err
+		}
+
+		for setting := range tlsEnvVars {
+			if value, ok := service.Settings[setting]; ok {
+				settings[setting] = value
+			}
+		}
+	}
+
+	return TLSSettings{
+		SSLMode:     settings["sslmode"],
+		SSLRootCert: settings["sslrootcert"],
+		SSLCert:     settings["sslcert"],
+		SSLKey:      settings["sslkey"],
+		SSLPassword: settings["sslpassword"],
+		SSLSNI:      settings["sslsni"] != "0",
+	}, nil
+}
+
+// readService reads serviceName from the service file named by PGSERVICEFILE, or else from ~/.pg_service.conf.
+func readService(serviceName string) (*pgservicefile.Service, error) {
+	serviceFilePath := os.Getenv("PGSERVICEFILE")
+	if serviceFilePath == "" {
+		currentUser, err := user.Current()
+		if err != nil {
+			return nil, fmt.Errorf("unable to find the service file of service '%s': %w", serviceName, err)
+		}
+		serviceFilePath = filepath.Join(currentUser.HomeDir, ".pg_service.conf")
+	}
+
+	serviceFile, err := pgservicefile.ReadServicefile(serviceFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the service file '%s': %w", serviceFilePath, err)
+	}
+
+	service, err := serviceFile.GetService(serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find service '%s' in '%s': %w", serviceName, serviceFilePath, err)
+	}
+
+	return service, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
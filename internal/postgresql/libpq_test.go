@@ -0,0 +1,150 @@
+package postgresql
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnString(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		settings       map[string]string
+		expectedOutput string
+	}{
+		{
+			testName:       "Sorted keywords",
+			settings:       map[string]string{"user": "terraform", "host": "localhost", "dbname": "terraform_test"},
+			expectedOutput: "dbname='terraform_test' host='localhost' user='terraform'",
+		},
+		{
+			testName:       "Spaces and quotes",
+			settings:       map[string]string{"user": "it's a user"},
+			expectedOutput: `user='it\'s a user'`,
+		},
+		{
+			testName:       "Backslashes",
+			settings:       map[string]string{"dbname": `C:\data`},
+			expectedOutput: `dbname='C:\\data'`,
+		},
+		{
+			testName:       "Empty value",
+			settings:       map[string]string{"dbname": ""},
+			expectedOutput: "dbname=''",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expectedOutput, ConnString(testCase.settings))
+		})
+	}
+}
+
+func TestConnStringParsing(t *testing.T) {
+	t.Parallel()
+
+	user := `us'er \"name" = x`
+	database := `db name\'; host=evil`
+
+	config, err := pgconn.ParseConfig(ConnString(map[string]string{
+		"host":   "db.example.com",
+		"user":   user,
+		"dbname": database,
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, "db.example.com", config.Host)
+	assert.Equal(t, user, config.User)
+	assert.Equal(t, database, config.Database)
+}
+
+func TestDefaultTLSSettings(t *testing.T) {
+	serviceFile := filepath.Join(t.TempDir(), "pg_service.conf")
+	require.NoError(t, os.WriteFile(serviceFile, []byte(`[app]
+host=db.example.com
+sslmode=verify-full
+sslrootcert=/etc/ssl/app-ca.crt
+`), 0o600))
+
+	testCases := []struct {
+		testName         string
+		env              map[string]string
+		expectedSettings TLSSettings
+		expectedErr      bool
+	}{
+		{
+			testName:         "No environment",
+			expectedSettings: TLSSettings{SSLSNI: true},
+		},
+		{
+			testName: "Environment variables",
+			env: map[string]string{
+				"PGSSLMODE":     "require",
+				"PGSSLCERT":     "/etc/ssl/client.crt",
+				"PGSSLKEY":      "/etc/ssl/client.key",
+				"PGSSLPASSWORD": "secret",
+				"PGSSLSNI":      "0",
+			},
+			expectedSettings: TLSSettings{
+				SSLMode:     "require",
+				SSLCert:     "/etc/ssl/client.crt",
+				SSLKey:      "/etc/ssl/client.key",
+				SSLPassword: "secret",
+			},
+		},
+		{
+			testName: "Service overrides environment variables",
+			env: map[string]string{
+				"PGSERVICE":     "app",
+				"PGSERVICEFILE": serviceFile,
+				"PGSSLMODE":     "require",
+				"PGSSLCERT":     "/etc/ssl/client.crt",
+			},
+			expectedSettings: TLSSettings{
+				SSLMode:     "verify-full",
+				SSLRootCert: "/etc/ssl/app-ca.crt",
+				SSLCert:     "/etc/ssl/client.crt",
+				SSLSNI:      true,
+			},
+		},
+		{
+			testName: "Unknown service",
+			env: map[string]string{
+				"PGSERVICE":     "missing",
+				"PGSERVICEFILE": serviceFile,
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			for _, envVar := range []string{"PGSERVICE", "PGSERVICEFILE", "PGSSLMODE", "PGSSLROOTCERT", "PGSSLCERT", "PGSSLKEY", "PGSSLPASSWORD", "PGSSLSNI"} {
+				t.Setenv(envVar, testCase.env[envVar])
+			}
+
+			settings, err := DefaultTLSSettings()
+			if testCase.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			// The certificates of the user running the tests may be found in ~/.postgresql.
+			if testCase.expectedSettings.SSLRootCert == "" {
+				settings.SSLRootCert = ""
+			}
+			if testCase.expectedSettings.SSLCert == "" {
+				settings.SSLCert, settings.SSLKey = "", ""
+			}
+			assert.Equal(t, testCase.expectedSettings, settings)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package postgresql
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	PasswordEncryptionScramSha256 = "scram-sha-256"
+	PasswordEncryptionMd5         = "md5"
+
+	// scramIterations matches the default of the server's scram_iterations setting.
+	scramIterations = 4096
+	scramSaltLength = 16
+)
+
+// EncryptPassword hashes a password the same way the server would for the given password_encryption setting, so that
+// the plaintext password never has to be sent to the server. Servers prior to Postgres 14 report "on" for MD5.
+func EncryptPassword(password string, roleName string, passwordEncryption string) (string, error) {
+	switch strings.ToLower(passwordEncryption) {
+	case PasswordEncryptionMd5, "on":
+		return Md5Password(password, roleName), nil
+	default:
+		salt := make([]byte, scramSaltLength)
+		if _, err := rand.Read(salt); err != nil {
+			return "", fmt.Errorf("unable to generate a salt for the password: %w", err)
+		}
+		return ScramSha256Password(password, salt, scramIterations), nil
+	}
+}
+
+// IsEncryptedPassword reports whether a password is already in one of the hashed formats the server stores, in which
+// case the server uses it as-is.
+func IsEncryptedPassword(password string) bool {
+	isMd5 := len(password) == 35 && strings.HasPrefix(password, "md5")
+
+	return isMd5 || strings.HasPrefix(password, "SCRAM-SHA-256$")
+}
+
+// Md5Password returns the MD5 hash of a password in the format stored by the server. As the role name is used as the
+// salt, the hash is no longer valid once the role is renamed.
+func Md5Password(password string, roleName string) string {
+	sum := md5.Sum([]byte(password + roleName))
+
+	return "md5" + hex.EncodeToString(sum[:])
+}
+
+// ScramSha256Password returns the SCRAM-SHA-256 verifier of a password in the format stored by the server, as
+// described in RFC 5803.
+func ScramSha256Password(password string, salt []byte, iterations int) string {
+	saltedPassword := pbkdf2.Key([]byte(saslPrep(password)), salt, iterations, sha256.Size, sha256.New)
+
+	clientKey := hmacSha256(saltedPassword, "Client Key")
+	storedKey := sha256.Sum256(clientKey)
+	serverKey := hmacSha256(saltedPassword, "Server Key")
+
+	return fmt.Sprintf("SCRAM-SHA-256$%d:%s$%s:%s",
+		iterations,
+		base64.StdEncoding.EncodeToString(salt),
+		base64.StdEncoding.EncodeToString(storedKey[:]),
+		base64.StdEncoding.EncodeToString(serverKey),
+	)
+}
+
+func hmacSha256(key []byte, message string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(message))
+
+	return mac.Sum(nil)
+}
+
+// saslPrep normalizes a password like the server does before hashing it with SCRAM (RFC 4013). Like the server, it
+// falls back to the password as-is when it contains prohibited characters.
+func saslPrep(password string) string {
+	isASCII := true
+	for _, char := range password {
+		if char > unicode.MaxASCII {
+			isASCII = false
+			break
+		}
+	}
+
+	// Printable ASCII passwords are unchanged by SASLprep, and passwords with ASCII control characters are prohibited.
+	if isASCII {
+		return password
+	}
+
+	var mapped strings.Builder
+	for _, char := range password {
+		switch {
+		case isMappedToNothing(char):
+			continue
+		case char != ' ' && unicode.Is(unicode.Zs, char):
+			mapped.WriteRune(' ')
+		default:
+			mapped.WriteRune(char)
+		}
+	}
+
+	normalized := norm.NFKC.String(mapped.String())
+
+	for _, char := range normalized {
+		if isProhibited(char) {
+			return password
+		}
+	}
+
+	return normalized
+}
+
+// isMappedToNothing reports whether a character is listed in table B.1 of RFC 3454.
+func isMappedToNothing(char rune) bool {
+	switch char {
+	case 0x00AD, 0x034F, 0x1806, 0x180B, 0x180C, 0x180D, 0x200B, 0x200C, 0x200D, 0x2060, 0xFEFF:
+		return true
+	}
+
+	return char >= 0xFE00 && char <= 0xFE0F
+}
+
+// isProhibited reports whether a character is prohibited by SASLprep, i.e. a control, private use, non-character,
+// surrogate or tagging character.
+func isProhibited(char rune) bool {
+	switch {
+	case unicode.IsControl(char):
+		return true
+	case unicode.Is(unicode.Co, char), unicode.Is(unicode.Cs, char):
+		return true
+	case char >= 0xFDD0 && char <= 0xFDEF, char&0xFFFE == 0xFFFE:
+		return true
+	case char == 0x0340, char == 0x0341, char == 0x200E, char == 0x200F:
+		return true
+	case char >= 0x202A && char <= 0x202E, char >= 0x206A && char <= 0x206F:
+		return true
+	case char == 0xE0001, char >= 0xE0020 && char <= 0xE007F:
+		return true
+	}
+
+	return false
+}
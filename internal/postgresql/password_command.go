@@ -0,0 +1,76 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// DefaultPasswordCommandTimeout is how long a password command may run when no timeout is set.
+	DefaultPasswordCommandTimeout = 30 * time.Second
+	// passwordCommandWaitDelay is how long the output of a password command is still read after it exited or was
+	// killed, in case it started processes which didn't.
+	passwordCommandWaitDelay = time.Second
+)
+
+// PasswordCommand gets the password of each new connection from the standard output of a command, so that short-lived
+// credentials never need to be stored.
+type PasswordCommand struct {
+	// Args are the program and arguments of the command, which is run without a shell.
+	Args []string
+	// Timeout is how long the command may run before it's killed.
+	Timeout time.Duration
+}
+
+// BeforeConnect sets the password of connConfig to the output of the command. It's meant to be used as the
+// BeforeConnect hook of a pgxpool.Config.
+func (c PasswordCommand) BeforeConnect(ctx context.Context, connConfig *pgx.ConnConfig) error {
+	password, err := c.Password(ctx)
+	if err != nil {
+		return err
+	}
+
+	connConfig.Password = password
+
+	return nil
+}
+
+// Password runs the command and returns its output without the trailing newline.
+func (c PasswordCommand) Password(ctx context.Context) (string, error) {
+	if len(c.Args) == 0 {
+		return "", errors.New("the password command is empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, c.Args[0], c.Args[1:]...)
+	cmd.WaitDelay = passwordCommandWaitDelay
+
+	// The output holds the password, so it's never part of the errors.
+	output, err := cmd.Output()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("the password command '%s' didn't complete within %s", c.Args[0], c.Timeout)
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return "", fmt.Errorf("the password command '%s' failed with %s: %s", c.Args[0], exitErr.ProcessState, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	if err != nil {
+		return "", fmt.Errorf("unable to run the password command '%s': %w", c.Args[0], err)
+	}
+
+	password := strings.TrimRight(string(output), "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("the password command '%s' didn't output a password", c.Args[0])
+	}
+
+	return password, nil
+}
@@ -0,0 +1,95 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordCommand(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		args             []string
+		expectedPassword string
+		expectedErr      string
+	}{
+		{
+			testName:         "Trailing newline",
+			args:             []string{"echo", "not_a_real_password"},
+			expectedPassword: "not_a_real_password",
+		},
+		{
+			testName:         "Spaces and CRLF",
+			args:             []string{"sh", "-c", `printf ' pass word \r\n'`},
+			expectedPassword: " pass word ",
+		},
+		{
+			testName:    "Failure",
+			args:        []string{"sh", "-c", "echo hunter2; echo 'no such secret' >&2; exit 3"},
+			expectedErr: "failed with exit status 3: no such secret",
+		},
+		{
+			testName:    "Empty output",
+			args:        []string{"true"},
+			expectedErr: "didn't output a password",
+		},
+		{
+			testName:    "Timeout",
+			args:        []string{"sleep", "10"},
+			expectedErr: "didn't complete within 100ms",
+		},
+		{
+			testName:    "Unknown program",
+			args:        []string{"/nonexistent/password-command"},
+			expectedErr: "unable to run the password command",
+		},
+		{
+			testName:    "Empty command",
+			expectedErr: "the password command is empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			command := PasswordCommand{Args: testCase.args, Timeout: 100 * time.Millisecond}
+			if testCase.expectedErr == "" {
+				command.Timeout = 10 * time.Second
+			}
+
+			password, err := command.Password(context.Background())
+			if testCase.expectedErr != "" {
+				require.ErrorContains(t, err, testCase.expectedErr)
+				assert.NotContains(t, err.Error(), "hunter2")
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedPassword, password)
+		})
+	}
+}
+
+func TestPasswordCommandBeforeConnect(t *testing.T) {
+	t.Parallel()
+
+	// The command outputs a new password each time it's run.
+	counter := filepath.Join(t.TempDir(), "counter")
+	command := PasswordCommand{
+		Args:    []string{"sh", "-c", fmt.Sprintf(`echo x >> '%s'; echo "password_$(wc -l < '%s' | tr -d ' ')"`, counter, counter)},
+		Timeout: 10 * time.Second,
+	}
+
+	for i := 1; i <= 3; i++ {
+		connConfig := &pgx.ConnConfig{}
+		require.NoError(t, command.BeforeConnect(context.Background(), connConfig))
+		assert.Equal(t, fmt.Sprintf("password_%d", i), connConfig.Password)
+	}
+}
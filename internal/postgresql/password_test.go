@@ -0,0 +1,92 @@
+package postgresql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScramSha256Password(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		password       string
+		salt           []byte
+		expectedOutput string
+	}{
+		{
+			testName:       "ASCII password",
+			password:       "not_a_real_password",
+			salt:           []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+			expectedOutput: "SCRAM-SHA-256$4096:AAECAwQFBgcICQoLDA0ODw==$se0DxRNwT/77MJr/28hVGeKYnIbLHsw/EOVYECynFhU=:66TCLU5Pxcwn5bLzSi2n9ZPgw+mcLANeXoWYsGgk0EU=",
+		},
+		{
+			testName:       "Non-ASCII password",
+			password:       "pässwörd",
+			salt:           []byte("0123456789abcdef"),
+			expectedOutput: "SCRAM-SHA-256$4096:MDEyMzQ1Njc4OWFiY2RlZg==$3fAV27j/YomKockTpuTuKl8RWKrwUX4tGo6Bmy3IHkQ=:LakBVUYurAovdTX6//mRi+yyjfF4zqUVQreGOY7pUPI=",
+		},
+		{
+			testName:       "Decomposed non-ASCII password is normalized",
+			password:       "pa\u0308sswo\u0308rd",
+			salt:           []byte("0123456789abcdef"),
+			expectedOutput: "SCRAM-SHA-256$4096:MDEyMzQ1Njc4OWFiY2RlZg==$3fAV27j/YomKockTpuTuKl8RWKrwUX4tGo6Bmy3IHkQ=:LakBVUYurAovdTX6//mRi+yyjfF4zqUVQreGOY7pUPI=",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			actualOutput := ScramSha256Password(testCase.password, testCase.salt, 4096)
+
+			assert.Equal(t, testCase.expectedOutput, actualOutput)
+		})
+	}
+}
+
+func TestMd5Password(t *testing.T) {
+	assert.Equal(t, "md5b85f9be3c5144024e714f44c4eada375", Md5Password("secret", "app_user"))
+}
+
+func TestEncryptPassword(t *testing.T) {
+	testCases := []struct {
+		testName           string
+		passwordEncryption string
+		expectedPrefix     string
+	}{
+		{
+			testName:           "SCRAM-SHA-256",
+			passwordEncryption: "scram-sha-256",
+			expectedPrefix:     "SCRAM-SHA-256$4096:",
+		},
+		{
+			testName:           "MD5",
+			passwordEncryption: "md5",
+			expectedPrefix:     "md5",
+		},
+		{
+			testName:           "MD5 prior to Postgres 14",
+			passwordEncryption: "on",
+			expectedPrefix:     "md5",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			actualOutput, err := EncryptPassword("secret", "app_user", testCase.passwordEncryption)
+
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(actualOutput, testCase.expectedPrefix))
+			assert.NotContains(t, actualOutput, "secret")
+			assert.True(t, IsEncryptedPassword(actualOutput))
+		})
+	}
+}
+
+func TestIsEncryptedPassword(t *testing.T) {
+	assert.False(t, IsEncryptedPassword("secret"))
+	assert.False(t, IsEncryptedPassword("md5-but-not-a-hash"))
+}
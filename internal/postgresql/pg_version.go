@@ -3,6 +3,8 @@ package postgresql
 import (
 	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
 )
 
 func ParsePostgresVersion(version string) (string, error) {
@@ -20,3 +22,16 @@ func ParsePostgresVersion(version string) (string, error) {
 	}
 	return "", fmt.Errorf("output of `SELECT VERSION();`: '%s', didn't match expected patterns", version)
 }
+
+// ParseMajorVersion returns the major version number of a version string as returned by ParsePostgresVersion,
+// e.g. 17 for "17.4".
+func ParseMajorVersion(version string) (int, error) {
+	major, _, _ := strings.Cut(version, ".")
+
+	majorVersion, err := strconv.Atoi(major)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse major version from '%s': %w", version, err)
+	}
+
+	return majorVersion, nil
+}
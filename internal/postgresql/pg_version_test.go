@@ -44,3 +44,44 @@ func TestParseDbVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestParseMajorVersion(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		input          string
+		expectedOutput int
+	}{
+		{
+			testName:       "Major and minor version",
+			input:          "17.4",
+			expectedOutput: 17,
+		},
+		{
+			testName:       "Major version only",
+			input:          "16",
+			expectedOutput: 16,
+		},
+		{
+			testName:       "Legacy three-part version",
+			input:          "9.6.24",
+			expectedOutput: 9,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			actualOutput, err := ParseMajorVersion(testCase.input)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedOutput, actualOutput)
+		})
+	}
+}
+
+func TestParseMajorVersionInvalid(t *testing.T) {
+	_, err := ParseMajorVersion("not-a-version")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,57 @@
+// Package pgsql builds the SQL sent to the server. Names and values coming from the configuration are never
+// interpolated as-is: identifiers are quoted the same way as pgx.Identifier, and values are passed as bind parameters
+// or, for utility statements such as CREATE ROLE which don't support bind parameters, quoted as string literals.
+package pgsql
+
+import (
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Statement is a SQL statement along with the values of its bind parameters.
+type Statement struct {
+	SQL  string
+	Args []any
+}
+
+// NewStatement returns a statement whose $1, $2, ... placeholders are bound to args.
+func NewStatement(sql string, args ...any) Statement {
+	return Statement{SQL: sql, Args: args}
+}
+
+// Identifier quotes a name for use as an identifier, e.g. a role, database or schema name. The name is always quoted,
+// so that its case is preserved and it can't be mistaken for a keyword.
+func Identifier(name string) string {
+	return pgx.Identifier{name}.Sanitize()
+}
+
+// QualifiedIdentifier quotes each part of a qualified name, e.g. a schema and a table name, and joins them with dots.
+func QualifiedIdentifier(parts ...string) string {
+	return pgx.Identifier(parts).Sanitize()
+}
+
+// Grantee quotes a role name for use as the grantee of GRANT and REVOKE, where PUBLIC is a keyword rather than a
+// role name.
+func Grantee(role string) string {
+	if strings.EqualFold(role, "public") {
+		return "PUBLIC"
+	}
+
+	return Identifier(role)
+}
+
+// Literal quotes a value for use as a string literal, doubling embedded quotes and using the escape string syntax
+// when the value contains backslashes, so that it's read correctly whatever standard_conforming_strings is set to.
+// Prefer bind parameters where the statement supports them.
+func Literal(value string) string {
+	// The server rejects NUL characters in text, so they are dropped like pgx.Identifier does.
+	value = strings.ReplaceAll(value, "\x00", "")
+	quoted := "'" + strings.ReplaceAll(value, "'", "''") + "'"
+
+	if strings.Contains(value, `\`) {
+		return "E" + strings.ReplaceAll(quoted, `\`, `\\`)
+	}
+
+	return quoted
+}
@@ -0,0 +1,152 @@
+package pgsql
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIdentifier(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		input          string
+		expectedOutput string
+	}{
+		{
+			testName:       "Lowercase name",
+			input:          "app",
+			expectedOutput: `"app"`,
+		},
+		{
+			testName:       "Mixed case name",
+			input:          "AppUser",
+			expectedOutput: `"AppUser"`,
+		},
+		{
+			testName:       "Hyphens and spaces",
+			input:          "app-user reader",
+			expectedOutput: `"app-user reader"`,
+		},
+		{
+			testName:       "Keyword",
+			input:          "select",
+			expectedOutput: `"select"`,
+		},
+		{
+			testName:       "Unicode",
+			input:          "utilisateur_é_日本",
+			expectedOutput: `"utilisateur_é_日本"`,
+		},
+		{
+			testName:       "Embedded double quotes",
+			input:          `app"user`,
+			expectedOutput: `"app""user"`,
+		},
+		{
+			testName:       "Injection attempt",
+			input:          `x"; DROP ROLE postgres; --`,
+			expectedOutput: `"x""; DROP ROLE postgres; --"`,
+		},
+		{
+			testName:       "NUL character",
+			input:          "app\x00user",
+			expectedOutput: `"appuser"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expectedOutput, Identifier(testCase.input))
+		})
+	}
+}
+
+func TestQualifiedIdentifier(t *testing.T) {
+	assert.Equal(t, `"Sales"."order""s"`, QualifiedIdentifier("Sales", `order"s`))
+	assert.Equal(t, `"app"."tenant"`, QualifiedIdentifier("app", "tenant"))
+}
+
+func TestGrantee(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		input          string
+		expectedOutput string
+	}{
+		{
+			testName:       "Public keyword",
+			input:          "public",
+			expectedOutput: "PUBLIC",
+		},
+		{
+			testName:       "Uppercase public keyword",
+			input:          "PUBLIC",
+			expectedOutput: "PUBLIC",
+		},
+		{
+			testName:       "Role name",
+			input:          "Reader",
+			expectedOutput: `"Reader"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expectedOutput, Grantee(testCase.input))
+		})
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		input          string
+		expectedOutput string
+	}{
+		{
+			testName:       "Plain value",
+			input:          "en_US.UTF-8",
+			expectedOutput: "'en_US.UTF-8'",
+		},
+		{
+			testName:       "Unicode",
+			input:          "mot de passe é 日本",
+			expectedOutput: "'mot de passe é 日本'",
+		},
+		{
+			testName:       "Embedded quotes",
+			input:          "it's",
+			expectedOutput: "'it''s'",
+		},
+		{
+			testName:       "Backslashes",
+			input:          `C:\data`,
+			expectedOutput: `E'C:\\data'`,
+		},
+		{
+			testName:       "Injection attempt",
+			input:          `x'; DROP ROLE postgres; --`,
+			expectedOutput: `'x''; DROP ROLE postgres; --'`,
+		},
+		{
+			testName:       "Backslash before a quote",
+			input:          `\'; DROP ROLE postgres; --`,
+			expectedOutput: `E'\\''; DROP ROLE postgres; --'`,
+		},
+		{
+			testName:       "NUL character",
+			input:          "a\x00b",
+			expectedOutput: "'ab'",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expectedOutput, Literal(testCase.input))
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DefaultPoolIdleTimeout is how long the pool of a database other than the provider's database is kept open while
+// it's not used.
+const DefaultPoolIdleTimeout = time.Minute
+
+// PoolManager lazily creates a connection pool per database, all sharing the connection settings of the provider's
+// pool, and caches them for reuse. The physical connections of all pools count towards a single budget, and pools
+// which are no longer used are closed to give their connections back.
+type PoolManager struct {
+	baseConfig  *pgxpool.Config
+	budget      *connectionBudget
+	idleTimeout time.Duration
+
+	mu    sync.Mutex
+	pools map[string]*managedPool
+}
+
+type managedPool struct {
+	pool     *pgxpool.Pool
+	lastUsed time.Time
+}
+
+// NewPoolManager returns a manager creating pools from config, whose database is the provider's database. When
+// maxConnections is greater than zero, it caps the number of connections open at once across all databases.
+func NewPoolManager(config *pgxpool.Config, maxConnections int32) *PoolManager {
+	manager := &PoolManager{
+		baseConfig:  config,
+		idleTimeout: DefaultPoolIdleTimeout,
+		pools:       map[string]*managedPool{},
+	}
+
+	if maxConnections > 0 {
+		manager.budget = newConnectionBudget(maxConnections)
+	}
+
+	return manager
+}
+
+// DatabaseName returns the name of the provider's database.
+func (m *PoolManager) DatabaseName() string {
+	return m.baseConfig.ConnConfig.Database
+}
+
+// Pool returns the connection pool of databaseName, creating it on first use. An empty databaseName returns the pool
+// of the provider's database. Connections are only opened once the pool is used.
+func (m *PoolManager) Pool(ctx context.Context, databaseName string) (*pgxpool.Pool, error) {
+	if databaseName == "" {
+		databaseName = m.DatabaseName()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closeIdlePools(databaseName)
+
+	if managed, ok := m.pools[databaseName]; ok {
+		managed.lastUsed = time.Now()
+		return managed.pool, nil
+	}
+
+	config := m.baseConfig.Copy()
+	config.ConnConfig.Database = databaseName
+
+	if databaseName != m.DatabaseName() {
+		config.MaxConnIdleTime = m.idleTimeout
+	}
+
+	if m.budget != nil {
+		config.MaxConns = m.budget.size
+		config.ConnConfig.DialFunc = m.budget.dialFunc(config.ConnConfig.DialFunc, func() { m.resetIdlePools(databaseName) })
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a connection pool for database '%s': %w", databaseName, err)
+	}
+
+	m.pools[databaseName] = &managedPool{pool: pool, lastUsed: time.Now()}
+
+	return pool, nil
+}
+
+// ClosePool closes the pool of databaseName, if it has one. The server refuses to drop or rename a database while
+// other sessions are connected to it, including the idle connections of its pool. The pool of the provider's database
+// is kept open.
+func (m *PoolManager) ClosePool(databaseName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if managed, ok := m.pools[databaseName]; ok && databaseName != m.DatabaseName() {
+		managed.pool.Close()
+		delete(m.pools, databaseName)
+	}
+}
+
+// Close closes the pools of all databases.
+func (m *PoolManager) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for databaseName, managed := range m.pools {
+		managed.pool.Close()
+		delete(m.pools, databaseName)
+	}
+}
+
+// closeIdlePools closes the pools, other than those of the provider's database and of databaseName, which haven't
+// been used for longer than the idle timeout and have no connection in use. The caller must hold m.mu.
+func (m *PoolManager) closeIdlePools(databaseName string) {
+	for name, managed := range m.pools {
+		if name == databaseName || name == m.DatabaseName() {
+			continue
+		}
+
+		if time.Since(managed.lastUsed) > m.idleTimeout && managed.pool.Stat().AcquiredConns() == 0 {
+			managed.pool.Close()
+			delete(m.pools, name)
+		}
+	}
+}
+
+// resetIdlePools closes the idle connections of the pools of other databases than databaseName which have no
+// connection in use, so that a connection to databaseName can be opened once the budget is exhausted.
+func (m *PoolManager) resetIdlePools(databaseName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, managed := range m.pools {
+		stat := managed.pool.Stat()
+		if name != databaseName && stat.AcquiredConns() == 0 && stat.IdleConns() > 0 {
+			managed.pool.Reset()
+		}
+	}
+}
+
+// connectionBudget limits the number of physical connections open at once. A slot of the budget is taken when a
+// connection is dialed, and given back when the connection is closed, whether it's closed by its pool or because the
+// connection failed to be established.
+type connectionBudget struct {
+	size  int32
+	slots chan struct{}
+}
+
+func newConnectionBudget(size int32) *connectionBudget {
+	return &connectionBudget{
+		size:  size,
+		slots: make(chan struct{}, size),
+	}
+}
+
+// acquire takes a slot of the budget. When the budget is exhausted, onExhausted is called once to let connections be
+// closed elsewhere, before waiting for a slot to be given back.
+func (b *connectionBudget) acquire(ctx context.Context, onExhausted func()) error {
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	if onExhausted != nil {
+		onExhausted()
+	}
+
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("all %d connections allowed by max_connections are in use: %w", b.size, ctx.Err())
+	}
+}
+
+func (b *connectionBudget) release() {
+	<-b.slots
+}
+
+// dialFunc wraps dial so that every connection it opens takes a slot of the budget.
+func (b *connectionBudget) dialFunc(dial pgconn.DialFunc, onExhausted func()) pgconn.DialFunc {
+	return func(ctx context.Context, network string, addr string) (net.Conn, error) {
+		if err := b.acquire(ctx, onExhausted); err != nil {
+			return nil, err
+		}
+
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			b.release()
+			return nil, err
+		}
+
+		return &budgetedConn{Conn: conn, release: b.release}, nil
+	}
+}
+
+// budgetedConn gives its slot back to the budget when it's closed.
+type budgetedConn struct {
+	net.Conn
+	release     func()
+	releaseOnce sync.Once
+}
+
+func (c *budgetedConn) Close() error {
+	err := c.Conn.Close()
+	c.releaseOnce.Do(c.release)
+
+	return err
+}
@@ -0,0 +1,152 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pipeDialer(ctx context.Context, network string, addr string) (net.Conn, error) {
+	client, server := net.Pipe()
+	_ = server.Close()
+
+	return client, nil
+}
+
+func TestConnectionBudget(t *testing.T) {
+	t.Parallel()
+
+	budget := newConnectionBudget(2)
+	dial := budget.dialFunc(pipeDialer, nil)
+
+	first, err := dial(context.Background(), "tcp", "localhost:5432")
+	require.NoError(t, err)
+	_, err = dial(context.Background(), "tcp", "localhost:5432")
+	require.NoError(t, err)
+
+	// The budget is exhausted until a connection is closed.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = dial(ctx, "tcp", "localhost:5432")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Closing a connection twice only gives its slot back once.
+	require.NoError(t, first.Close())
+	_ = first.Close()
+	assert.Len(t, budget.slots, 1)
+
+	_, err = dial(context.Background(), "tcp", "localhost:5432")
+	assert.NoError(t, err)
+}
+
+func TestConnectionBudgetFailedDial(t *testing.T) {
+	t.Parallel()
+
+	budget := newConnectionBudget(1)
+	dial := budget.dialFunc(func(ctx context.Context, network string, addr string) (net.Conn, error) {
+		return nil, errors.New("connection refused")
+	}, nil)
+
+	_, err := dial(context.Background(), "tcp", "localhost:5432")
+	assert.Error(t, err)
+	assert.Empty(t, budget.slots)
+}
+
+func TestConnectionBudgetExhausted(t *testing.T) {
+	t.Parallel()
+
+	budget := newConnectionBudget(1)
+
+	held, err := budget.dialFunc(pipeDialer, nil)(context.Background(), "tcp", "localhost:5432")
+	require.NoError(t, err)
+
+	// The callback closes connections elsewhere, e.g. the idle connections of other pools.
+	onExhaustedCalls := 0
+	dial := budget.dialFunc(pipeDialer, func() {
+		onExhaustedCalls++
+		_ = held.Close()
+	})
+
+	_, err = dial(context.Background(), "tcp", "localhost:5432")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, onExhaustedCalls)
+}
+
+func TestPoolManager(t *testing.T) {
+	t.Parallel()
+
+	config, err := pgxpool.ParseConfig("postgres://terraform@localhost:5432/terraform_test")
+	require.NoError(t, err)
+
+	manager := NewPoolManager(config, 10)
+	defer manager.Close()
+
+	ctx := context.Background()
+
+	defaultPool, err := manager.Pool(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, "terraform_test", defaultPool.Config().ConnConfig.Database)
+
+	samePool, err := manager.Pool(ctx, "terraform_test")
+	require.NoError(t, err)
+	assert.Same(t, defaultPool, samePool)
+
+	otherPool, err := manager.Pool(ctx, "other")
+	require.NoError(t, err)
+	assert.Equal(t, "other", otherPool.Config().ConnConfig.Database)
+	assert.Equal(t, int32(10), otherPool.Config().MaxConns)
+
+	// Pools which haven't been used for longer than the idle timeout are closed, except the provider's database pool.
+	manager.mu.Lock()
+	for _, managed := range manager.pools {
+		managed.lastUsed = time.Now().Add(-2 * manager.idleTimeout)
+	}
+	manager.mu.Unlock()
+
+	_, err = manager.Pool(ctx, "another")
+	require.NoError(t, err)
+
+	manager.mu.Lock()
+	assert.Contains(t, manager.pools, "terraform_test")
+	assert.NotContains(t, manager.pools, "other")
+	assert.Contains(t, manager.pools, "another")
+	manager.mu.Unlock()
+
+	reopenedPool, err := manager.Pool(ctx, "other")
+	require.NoError(t, err)
+	assert.NotSame(t, otherPool, reopenedPool)
+}
+
+func TestPoolManagerClosePool(t *testing.T) {
+	t.Parallel()
+
+	config, err := pgxpool.ParseConfig("postgres://terraform@localhost:5432/terraform_test")
+	require.NoError(t, err)
+
+	manager := NewPoolManager(config, 0)
+	defer manager.Close()
+
+	ctx := context.Background()
+
+	defaultPool, err := manager.Pool(ctx, "")
+	require.NoError(t, err)
+	otherPool, err := manager.Pool(ctx, "other")
+	require.NoError(t, err)
+
+	manager.ClosePool("other")
+	manager.ClosePool("terraform_test")
+
+	reopenedPool, err := manager.Pool(ctx, "other")
+	require.NoError(t, err)
+	assert.NotSame(t, otherPool, reopenedPool)
+
+	samePool, err := manager.Pool(ctx, "")
+	require.NoError(t, err)
+	assert.Same(t, defaultPool, samePool)
+}
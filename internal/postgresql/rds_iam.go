@@ -0,0 +1,188 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// RDSAuthTokenLifetime is how long RDS accepts an authentication token after it's signed.
+	RDSAuthTokenLifetime = 15 * time.Minute
+	// rdsAuthTokenExpiryMargin is how long before its expiry a token is re-signed, so that connections aren't opened
+	// with a token about to expire.
+	rdsAuthTokenExpiryMargin = 5 * time.Minute
+	// emptyPayloadHash is the SHA-256 hash of an empty payload, as the signed request has no body.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// AWSSettings are the settings used to get the AWS credentials signing RDS authentication tokens. Unset settings fall
+// back to the default AWS configuration, i.e. the AWS environment variables and shared configuration files.
+type AWSSettings struct {
+	Region          string
+	Profile         string
+	RoleARN         string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// RDSIAMAuth authenticates connections with RDS IAM authentication tokens, used as passwords. Tokens are signed when a
+// connection is opened, and reused by later connections to the same endpoint until they're close to expiring.
+type RDSIAMAuth struct {
+	region      string
+	credentials aws.CredentialsProvider
+	now         func() time.Time
+
+	mu     sync.Mutex
+	tokens map[string]rdsAuthToken
+}
+
+type rdsAuthToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// NewRDSIAMAuth returns the RDS IAM authentication using the credentials and region resolved from settings.
+func NewRDSIAMAuth(ctx context.Context, settings AWSSettings) (*RDSIAMAuth, error) {
+	var options []func(*config.LoadOptions) error
+	if settings.Region != "" {
+		options = append(options, config.WithRegion(settings.Region))
+	}
+	if settings.Profile != "" {
+		options = append(options, config.WithSharedConfigProfile(settings.Profile))
+	}
+	if settings.AccessKeyID != "" {
+		options = append(options, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(settings.AccessKeyID, settings.SecretAccessKey, settings.SessionToken),
+		))
+	}
+
+	awsConfig, err := config.LoadDefaultConfig(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the AWS configuration: %w", err)
+	}
+
+	if awsConfig.Region == "" {
+		return nil, errors.New("no AWS region is set, neither in the provider configuration nor in the AWS configuration")
+	}
+
+	credentialsProvider := awsConfig.Credentials
+	if settings.RoleARN != "" {
+		credentialsProvider = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(awsConfig), settings.RoleARN))
+	}
+
+	return newRDSIAMAuth(awsConfig.Region, credentialsProvider, time.Now), nil
+}
+
+func newRDSIAMAuth(region string, credentialsProvider aws.CredentialsProvider, now func() time.Time) *RDSIAMAuth {
+	return &RDSIAMAuth{
+		region:      region,
+		credentials: credentialsProvider,
+		now:         now,
+		tokens:      map[string]rdsAuthToken{},
+	}
+}
+
+// BeforeConnect sets the password of connConfig to an authentication token of its endpoint and user. It's meant to be
+// used as the BeforeConnect hook of a pgxpool.Config.
+func (a *RDSIAMAuth) BeforeConnect(ctx context.Context, connConfig *pgx.ConnConfig) error {
+	token, err := a.token(ctx, net.JoinHostPort(connConfig.Host, strconv.Itoa(int(connConfig.Port))), connConfig.User)
+	if err != nil {
+		return err
+	}
+
+	connConfig.Password = token
+
+	return nil
+}
+
+func (a *RDSIAMAuth) token(ctx context.Context, endpoint string, user string) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := user + "@" + endpoint
+	now := a.now()
+
+	if cached, ok := a.tokens[key]; ok && now.Before(cached.expiresAt.Add(-rdsAuthTokenExpiryMargin)) {
+		return cached.token, nil
+	}
+
+	awsCredentials, err := a.credentials.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve the AWS credentials: %w", err)
+	}
+
+	token, err := BuildRDSAuthToken(ctx, endpoint, a.region, user, awsCredentials, now)
+	if err != nil {
+		return "", err
+	}
+
+	// Tokens signed with expiring credentials expire sooner, along with the credentials.
+	expiresIn, err := rdsAuthTokenExpiresIn(awsCredentials, now)
+	if err != nil {
+		return "", err
+	}
+
+	a.tokens[key] = rdsAuthToken{token: token, expiresAt: now.Add(expiresIn)}
+
+	return token, nil
+}
+
+// BuildRDSAuthToken returns the authentication token of user on the RDS endpoint (host:port), signed with
+// awsCredentials at signingTime. The token is a pre-signed URL without its scheme, like those of the AWS CLI's
+// `aws rds generate-db-auth-token`.
+func BuildRDSAuthToken(ctx context.Context, endpoint string, region string, user string, awsCredentials aws.Credentials, signingTime time.Time) (string, error) {
+	expiresIn, err := rdsAuthTokenExpiresIn(awsCredentials, signingTime)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("Action", "connect")
+	query.Set("DBUser", user)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiresIn/time.Second)))
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+endpoint+"/?"+query.Encode(), nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to build the RDS authentication request: %w", err)
+	}
+
+	signedURL, _, err := v4.NewSigner().PresignHTTP(ctx, awsCredentials, request, emptyPayloadHash, "rds-db", region, signingTime)
+	if err != nil {
+		return "", fmt.Errorf("unable to sign the RDS authentication token: %w", err)
+	}
+
+	return signedURL[len("https://"):], nil
+}
+
+// rdsAuthTokenExpiresIn returns how long a token signed with awsCredentials at signingTime is valid, which is
+// RDSAuthTokenLifetime unless the credentials expire sooner.
+func rdsAuthTokenExpiresIn(awsCredentials aws.Credentials, signingTime time.Time) (time.Duration, error) {
+	expiresIn := RDSAuthTokenLifetime
+	if awsCredentials.CanExpire {
+		// The token is only valid as long as the credentials which signed it.
+		if credentialsLifetime := awsCredentials.Expires.Sub(signingTime).Truncate(time.Second); credentialsLifetime < expiresIn {
+			expiresIn = credentialsLifetime
+		}
+	}
+	if expiresIn <= 0 {
+		return 0, errors.New("unable to sign an RDS authentication token: the AWS credentials have expired")
+	}
+
+	return expiresIn, nil
+}
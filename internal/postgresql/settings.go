@@ -0,0 +1,160 @@
+package postgresql
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// listSettings are the configuration parameters whose value is a list of names, which have to be given as separate
+// values in `SET name = value, ...` rather than as a single string.
+var listSettings = map[string]bool{
+	"local_preload_libraries":   true,
+	"search_path":               true,
+	"session_preload_libraries": true,
+	"temp_tablespaces":          true,
+}
+
+var memoryUnits = map[string]float64{
+	"B":  1,
+	"kB": 1 << 10,
+	"MB": 1 << 20,
+	"GB": 1 << 30,
+	"TB": 1 << 40,
+}
+
+var timeUnits = map[string]float64{
+	"us":  1,
+	"ms":  1e3,
+	"s":   1e6,
+	"min": 60e6,
+	"h":   3600e6,
+	"d":   86400e6,
+}
+
+var numericSettingRegex = regexp.MustCompile(`^\s*([-+]?(?:\d+\.?\d*|\.\d+)(?:[eE][-+]?\d+)?)\s*([a-zA-Z]*)\s*$`)
+var baseUnitRegex = regexp.MustCompile(`^(\d*)([a-zA-Z]+)$`)
+
+// IsListSetting reports whether a configuration parameter takes a list of names as its value, like search_path.
+func IsListSetting(name string) bool {
+	return listSettings[strings.ToLower(name)]
+}
+
+// SplitListSetting splits the value of a list parameter, e.g. `"$user", public`, into its elements, removing the
+// double quotes around quoted elements.
+func SplitListSetting(value string) []string {
+	var elements []string
+	var element strings.Builder
+	inQuotes := false
+
+	runes := []rune(value)
+	for i := 0; i < len(runes); i++ {
+		char := runes[i]
+		switch {
+		case char == '"' && inQuotes && i+1 < len(runes) && runes[i+1] == '"':
+			element.WriteRune('"')
+			i++
+		case char == '"':
+			inQuotes = !inQuotes
+		case char == ',' && !inQuotes:
+			elements = append(elements, strings.TrimSpace(element.String()))
+			element.Reset()
+		case char == ' ' && !inQuotes:
+			// Whitespace outside of quotes only separates elements.
+		default:
+			element.WriteRune(char)
+		}
+	}
+
+	if trimmed := strings.TrimSpace(element.String()); trimmed != "" || len(elements) > 0 {
+		elements = append(elements, trimmed)
+	}
+
+	return elements
+}
+
+// SettingValuesEqual reports whether two values of the configuration parameter name are equivalent, e.g. `1min` and
+// `60s` for statement_timeout. vartype and unit are the columns of the parameter in pg_settings, and are empty for
+// parameters unknown to the server.
+func SettingValuesEqual(name string, a string, b string, vartype string, unit string) bool {
+	if a == b {
+		return true
+	}
+
+	switch {
+	case IsListSetting(name):
+		return strings.Join(SplitListSetting(a), ",") == strings.Join(SplitListSetting(b), ",")
+	case vartype == "bool":
+		boolA, okA := parseBoolSetting(a)
+		boolB, okB := parseBoolSetting(b)
+		return okA && okB && boolA == boolB
+	case vartype == "enum":
+		return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+	case vartype == "integer" || vartype == "real":
+		numberA, okA := parseNumericSetting(a, unit)
+		numberB, okB := parseNumericSetting(b, unit)
+		return okA && okB && math.Abs(numberA-numberB) <= 1e-9*math.Max(math.Abs(numberA), math.Abs(numberB))
+	}
+
+	return false
+}
+
+// parseBoolSetting parses a boolean parameter value the way the server does, accepting unique prefixes of true,
+// false, yes, no, on and off, as well as 1 and 0.
+func parseBoolSetting(value string) (bool, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	switch {
+	case value == "":
+		return false, false
+	case value == "1", value == "on", strings.HasPrefix("true", value), strings.HasPrefix("yes", value):
+		return true, true
+	case value == "0", value == "off", strings.HasPrefix("false", value), strings.HasPrefix("no", value):
+		return false, true
+	}
+
+	return false, false
+}
+
+// parseNumericSetting converts a numeric parameter value to the smallest unit of its kind, i.e. bytes or microseconds.
+// A value without a unit is in baseUnit, the unit of the parameter as reported by pg_settings, such as `ms` or `8kB`.
+func parseNumericSetting(value string, baseUnit string) (float64, bool) {
+	matches := numericSettingRegex.FindStringSubmatch(value)
+	if matches == nil {
+		return 0, false
+	}
+
+	number, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, false
+	}
+
+	baseMultiplier, baseUnitName := 1.0, ""
+	if baseUnitMatches := baseUnitRegex.FindStringSubmatch(baseUnit); baseUnitMatches != nil {
+		baseUnitName = baseUnitMatches[2]
+		if baseUnitMatches[1] != "" {
+			baseMultiplier, _ = strconv.ParseFloat(baseUnitMatches[1], 64)
+		}
+	}
+
+	units := timeUnits
+	if _, isMemory := memoryUnits[baseUnitName]; isMemory {
+		units = memoryUnits
+	}
+
+	valueUnit := matches[2]
+	if valueUnit == "" {
+		if multiplier, ok := units[baseUnitName]; ok {
+			return number * baseMultiplier * multiplier, true
+		}
+		return number, baseUnitName == ""
+	}
+
+	multiplier, ok := units[valueUnit]
+	if !ok {
+		return 0, false
+	}
+
+	return number * multiplier, true
+}
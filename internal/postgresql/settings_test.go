@@ -0,0 +1,165 @@
+package postgresql
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSplitListSetting(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		input          string
+		expectedOutput []string
+	}{
+		{
+			testName:       "Empty value",
+			input:          "",
+			expectedOutput: nil,
+		},
+		{
+			testName:       "Single element",
+			input:          "public",
+			expectedOutput: []string{"public"},
+		},
+		{
+			testName:       "Quoted elements",
+			input:          `"$user", public`,
+			expectedOutput: []string{"$user", "public"},
+		},
+		{
+			testName:       "Quoted element with a comma and escaped quotes",
+			input:          `"a, ""b""",c`,
+			expectedOutput: []string{`a, "b"`, "c"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expectedOutput, SplitListSetting(testCase.input))
+		})
+	}
+}
+
+func TestSettingValuesEqual(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		name           string
+		a              string
+		b              string
+		vartype        string
+		unit           string
+		expectedOutput bool
+	}{
+		{
+			testName:       "Time units",
+			name:           "statement_timeout",
+			a:              "1min",
+			b:              "60s",
+			vartype:        "integer",
+			unit:           "ms",
+			expectedOutput: true,
+		},
+		{
+			testName:       "Time without unit is in the base unit",
+			name:           "statement_timeout",
+			a:              "1500",
+			b:              "1.5s",
+			vartype:        "integer",
+			unit:           "ms",
+			expectedOutput: true,
+		},
+		{
+			testName:       "Different times",
+			name:           "statement_timeout",
+			a:              "1min",
+			b:              "61s",
+			vartype:        "integer",
+			unit:           "ms",
+			expectedOutput: false,
+		},
+		{
+			testName:       "Memory with a block-sized base unit",
+			name:           "effective_cache_size",
+			a:              "1GB",
+			b:              "131072",
+			vartype:        "integer",
+			unit:           "8kB",
+			expectedOutput: true,
+		},
+		{
+			testName:       "Memory units",
+			name:           "work_mem",
+			a:              "64MB",
+			b:              "65536kB",
+			vartype:        "integer",
+			unit:           "kB",
+			expectedOutput: true,
+		},
+		{
+			testName:       "Invalid unit",
+			name:           "work_mem",
+			a:              "64MB",
+			b:              "64 parsecs",
+			vartype:        "integer",
+			unit:           "kB",
+			expectedOutput: false,
+		},
+		{
+			testName:       "Booleans",
+			name:           "jit",
+			a:              "on",
+			b:              "true",
+			vartype:        "bool",
+			expectedOutput: true,
+		},
+		{
+			testName:       "Different booleans",
+			name:           "jit",
+			a:              "off",
+			b:              "yes",
+			vartype:        "bool",
+			expectedOutput: false,
+		},
+		{
+			testName:       "Enums are case insensitive",
+			name:           "log_statement",
+			a:              "DDL",
+			b:              "ddl",
+			vartype:        "enum",
+			expectedOutput: true,
+		},
+		{
+			testName:       "Lists ignore whitespace and quoting",
+			name:           "search_path",
+			a:              "app,public",
+			b:              `"app", public`,
+			vartype:        "string",
+			expectedOutput: true,
+		},
+		{
+			testName:       "Strings are compared as-is",
+			name:           "application_name",
+			a:              "App",
+			b:              "app",
+			vartype:        "string",
+			expectedOutput: false,
+		},
+		{
+			testName:       "Unknown parameters are compared as-is",
+			name:           "app.tenant",
+			a:              "1min",
+			b:              "60s",
+			expectedOutput: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expectedOutput, SettingValuesEqual(testCase.name, testCase.a, testCase.b, testCase.vartype, testCase.unit))
+		})
+	}
+}
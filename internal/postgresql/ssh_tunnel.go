@@ -0,0 +1,399 @@
+package postgresql
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// DefaultSSHPort is the port of SSH hosts which don't set one.
+const DefaultSSHPort = 22
+
+// SSHHost is a host of an SSH tunnel, with the user and private key used to log into it.
+type SSHHost struct {
+	Host string
+	// Port defaults to DefaultSSHPort.
+	Port int
+	// User defaults to the name of the operating system user.
+	User string
+	// PrivateKey is a path to a private key file, or PEM content.
+	PrivateKey           string
+	PrivateKeyPassphrase string
+}
+
+// SSHTunnelSettings are the settings of an SSH tunnel through a bastion host, reached through JumpHosts in turn when
+// there are any.
+type SSHTunnelSettings struct {
+	SSHHost
+	JumpHosts []SSHHost
+	// Agent authenticates with the keys of the SSH agent listening on SSH_AUTH_SOCK, in addition to the private key of
+	// each host.
+	Agent bool
+	// KnownHosts is a path to a known_hosts file, or its content, holding the keys of all the hosts. It defaults to
+	// ~/.ssh/known_hosts.
+	KnownHosts string
+}
+
+// SSHTunnel opens connections through an SSH tunnel. The SSH connections are only opened on first use, and are opened
+// again when they're lost.
+type SSHTunnel struct {
+	hops  []sshHop
+	agent *sshAgent
+
+	mu      sync.Mutex
+	clients []*ssh.Client
+}
+
+// sshHop is a host of the tunnel, along with the configuration used to log into it.
+type sshHop struct {
+	address string
+	config  *ssh.ClientConfig
+}
+
+// NewSSHTunnel returns a tunnel through the hosts of settings. The private keys and known hosts are read, but the
+// hosts aren't connected to.
+func NewSSHTunnel(settings SSHTunnelSettings) (*SSHTunnel, error) {
+	knownHostsPath := settings.KnownHosts
+	if knownHostsPath == "" {
+		currentUser, err := user.Current()
+		if err != nil {
+			return nil, fmt.Errorf("unable to find the known_hosts file: %w", err)
+		}
+		knownHostsPath = filepath.Join(currentUser.HomeDir, ".ssh", "known_hosts")
+	}
+
+	hostKeyCallback, err := readKnownHosts(knownHostsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	tunnel := &SSHTunnel{}
+	if settings.Agent {
+		tunnel.agent = &sshAgent{}
+	}
+
+	for _, host := range append(settings.JumpHosts, settings.SSHHost) {
+		hop, err := newSSHHop(host, tunnel.agent, hostKeyCallback)
+		if err != nil {
+			return nil, err
+		}
+		tunnel.hops = append(tunnel.hops, hop)
+	}
+
+	return tunnel, nil
+}
+
+func newSSHHop(host SSHHost, sshAgent *sshAgent, hostKeyCallback ssh.HostKeyCallback) (sshHop, error) {
+	port := host.Port
+	if port == 0 {
+		port = DefaultSSHPort
+	}
+	address := net.JoinHostPort(host.Host, strconv.Itoa(port))
+
+	userName := host.User
+	if userName == "" {
+		currentUser, err := user.Current()
+		if err != nil {
+			return sshHop{}, fmt.Errorf("unable to find the SSH user of '%s': %w", address, err)
+		}
+		userName = currentUser.Username
+	}
+
+	var authMethods []ssh.AuthMethod
+	if host.PrivateKey != "" {
+		signer, err := readSSHPrivateKey(host.PrivateKey, host.PrivateKeyPassphrase)
+		if err != nil {
+			return sshHop{}, fmt.Errorf("unable to read the SSH private key of '%s': %w", address, err)
+		}
+		authMethods = append(authMethods, ssh.PublicKeys(signer))
+	}
+	if sshAgent != nil {
+		authMethods = append(authMethods, ssh.PublicKeysCallback(sshAgent.signers))
+	}
+	if len(authMethods) == 0 {
+		return sshHop{}, fmt.Errorf("no private key nor SSH agent is set to log into '%s'", address)
+	}
+
+	return sshHop{
+		address: address,
+		config: &ssh.ClientConfig{
+			User:              userName,
+			Auth:              authMethods,
+			HostKeyCallback:   hostKeyCallback,
+			HostKeyAlgorithms: knownHostKeyAlgorithms(hostKeyCallback, address),
+		},
+	}, nil
+}
+
+// DialContext opens a connection to address from the bastion host, which resolves the host name. It's meant to be used
+// as the DialFunc of a pgconn.Config.
+func (t *SSHTunnel) DialContext(ctx context.Context, network string, address string) (net.Conn, error) {
+	client, err := t.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := client.DialContext(ctx, network, address)
+
+	// The SSH connection may have been lost without the tunnel noticing yet, in which case it's opened again. A
+	// rejected channel means that the bastion host is connected but couldn't reach address.
+	var openChannelErr *ssh.OpenChannelError
+	if err != nil && !errors.As(err, &openChannelErr) && ctx.Err() == nil {
+		t.reset(client)
+
+		if client, err = t.client(ctx); err != nil {
+			return nil, err
+		}
+		conn, err = client.DialContext(ctx, network, address)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to '%s' through the SSH tunnel: %w", address, err)
+	}
+
+	return conn, nil
+}
+
+// Close closes the SSH connections.
+func (t *SSHTunnel) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.closeClients()
+
+	if t.agent != nil {
+		t.agent.close()
+	}
+
+	return nil
+}
+
+// client returns the SSH client of the bastion host, logging into each host of the tunnel when it isn't connected.
+func (t *SSHTunnel) client(ctx context.Context) (*ssh.Client, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.clients) > 0 {
+		return t.clients[len(t.clients)-1], nil
+	}
+
+	var dialer net.Dialer
+	for i, hop := range t.hops {
+		var conn net.Conn
+		var err error
+		if i == 0 {
+			conn, err = dialer.DialContext(ctx, "tcp", hop.address)
+		} else {
+			conn, err = t.clients[i-1].DialContext(ctx, "tcp", hop.address)
+		}
+		if err != nil {
+			t.closeClients()
+			return nil, fmt.Errorf("unable to connect to SSH host '%s': %w", hop.address, err)
+		}
+
+		client, err := sshHandshake(ctx, conn, hop)
+		if err != nil {
+			t.closeClients()
+			return nil, fmt.Errorf("unable to log into SSH host '%s': %w", hop.address, err)
+		}
+
+		t.clients = append(t.clients, client)
+	}
+
+	// The tunnel is opened again by the next connection once any of its hosts is disconnected.
+	bastion := t.clients[len(t.clients)-1]
+	for _, client := range t.clients {
+		go func() {
+			_ = client.Wait()
+			t.reset(bastion)
+		}()
+	}
+
+	return bastion, nil
+}
+
+// reset closes the SSH connections of the tunnel whose bastion host client is bastion, unless they were already
+// replaced.
+func (t *SSHTunnel) reset(bastion *ssh.Client) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.clients) > 0 && t.clients[len(t.clients)-1] == bastion {
+		t.closeClients()
+	}
+}
+
+// closeClients closes the SSH connections, from the bastion host to the first jump host. The caller must hold t.mu.
+func (t *SSHTunnel) closeClients() {
+	for i := len(t.clients) - 1; i >= 0; i-- {
+		_ = t.clients[i].Close()
+	}
+	t.clients = nil
+}
+
+// sshHandshake logs into hop over conn, and closes conn when ctx is done before the handshake completes.
+func sshHandshake(ctx context.Context, conn net.Conn, hop sshHop) (*ssh.Client, error) {
+	handshakeDone := make(chan struct{})
+	defer close(handshakeDone)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-handshakeDone:
+		}
+	}()
+
+	clientConn, channels, requests, err := ssh.NewClientConn(conn, hop.address, hop.config)
+	if err != nil {
+		_ = conn.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+
+	return ssh.NewClient(clientConn, channels, requests), nil
+}
+
+// readKnownHosts returns the callback checking host keys against knownHosts, a path to a known_hosts file or its
+// content.
+func readKnownHosts(knownHosts string) (ssh.HostKeyCallback, error) {
+	if _, err := os.Stat(knownHosts); err == nil || !strings.Contains(knownHosts, " ") {
+		callback, err := knownhosts.New(knownHosts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the known_hosts file '%s': %w", knownHosts, err)
+		}
+		return callback, nil
+	}
+
+	// knownhosts only reads files, which it parses at once.
+	knownHostsFile, err := os.CreateTemp("", "known_hosts")
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the known hosts: %w", err)
+	}
+	defer func() {
+		_ = knownHostsFile.Close()
+		_ = os.Remove(knownHostsFile.Name())
+	}()
+
+	if _, err := knownHostsFile.WriteString(knownHosts); err != nil {
+		return nil, fmt.Errorf("unable to read the known hosts: %w", err)
+	}
+
+	callback, err := knownhosts.New(knownHostsFile.Name())
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the known hosts: %w", err)
+	}
+
+	return callback, nil
+}
+
+// knownHostKeyAlgorithms returns the algorithms of the known keys of address, so that the host is asked for one of
+// them rather than for a key of another type, which couldn't be verified. It returns nil when the host isn't known,
+// leaving the default algorithms.
+func knownHostKeyAlgorithms(hostKeyCallback ssh.HostKeyCallback, address string) []string {
+	// Checking a key which can't be known returns the known keys of the host.
+	probeKey, err := ssh.NewPublicKey(ed25519.PublicKey(make([]byte, ed25519.PublicKeySize)))
+	if err != nil {
+		return nil
+	}
+
+	var keyErr *knownhosts.KeyError
+	if err := hostKeyCallback(address, &net.TCPAddr{}, probeKey); !errors.As(err, &keyErr) {
+		return nil
+	}
+
+	var algorithms []string
+	for _, knownKey := range keyErr.Want {
+		switch keyType := knownKey.Key.Type(); keyType {
+		case ssh.KeyAlgoRSA:
+			algorithms = append(algorithms, ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA)
+		default:
+			algorithms = append(algorithms, keyType)
+		}
+	}
+
+	return algorithms
+}
+
+// readSSHPrivateKey returns the signer of privateKey, a path to a private key file or PEM content, decrypting it with
+// passphrase when it's encrypted.
+func readSSHPrivateKey(privateKey string, passphrase string) (ssh.Signer, error) {
+	pemBytes, err := readPEM(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if passphrase != "" {
+		return ssh.ParsePrivateKeyWithPassphrase(pemBytes, []byte(passphrase))
+	}
+
+	signer, err := ssh.ParsePrivateKey(pemBytes)
+	var passphraseMissingErr *ssh.PassphraseMissingError
+	if errors.As(err, &passphraseMissingErr) {
+		return nil, errors.New("the private key is encrypted, but no passphrase is set")
+	}
+
+	return signer, err
+}
+
+// sshAgent is the SSH agent listening on SSH_AUTH_SOCK, connected to on first use.
+type sshAgent struct {
+	mu     sync.Mutex
+	conn   net.Conn
+	client agent.ExtendedAgent
+}
+
+// signers returns the signers of the agent's keys.
+func (a *sshAgent) signers() ([]ssh.Signer, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.client == nil {
+		socket := os.Getenv("SSH_AUTH_SOCK")
+		if socket == "" {
+			return nil, errors.New("no SSH agent is running: SSH_AUTH_SOCK isn't set")
+		}
+
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect to the SSH agent: %w", err)
+		}
+
+		a.conn = conn
+		a.client = agent.NewClient(conn)
+	}
+
+	signers, err := a.client.Signers()
+	if err != nil {
+		// The agent is connected to again on the next login.
+		_ = a.conn.Close()
+		a.conn, a.client = nil, nil
+		return nil, fmt.Errorf("unable to list the keys of the SSH agent: %w", err)
+	}
+
+	return signers, nil
+}
+
+func (a *sshAgent) close() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.conn != nil {
+		_ = a.conn.Close()
+		a.conn, a.client = nil, nil
+	}
+}
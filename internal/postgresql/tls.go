@@ -0,0 +1,229 @@
+package postgresql
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	SSLModeDisable    = "disable"
+	SSLModeAllow      = "allow"
+	SSLModePrefer     = "prefer"
+	SSLModeRequire    = "require"
+	SSLModeVerifyCA   = "verify-ca"
+	SSLModeVerifyFull = "verify-full"
+)
+
+// SSLModes are the supported values of sslmode, with the same meaning as for libpq.
+var SSLModes = []string{SSLModeDisable, SSLModeAllow, SSLModePrefer, SSLModeRequire, SSLModeVerifyCA, SSLModeVerifyFull}
+
+// TLSSettings are the TLS settings of a connection, named after the libpq connection parameters. Certificates and
+// keys are either paths to PEM files, or PEM content.
+type TLSSettings struct {
+	SSLMode     string
+	SSLRootCert string
+	SSLCert     string
+	SSLKey      string
+	SSLPassword string
+	SSLSNI      bool
+}
+
+// ApplyTLSSettings replaces the TLS configuration of config and of its fallbacks with settings. Each host gets one
+// connection attempt per TLS configuration returned by TLSConfigs, e.g. first with and then without TLS for `prefer`.
+// Unix sockets are never encrypted, like with libpq.
+func ApplyTLSSettings(config *pgconn.Config, settings TLSSettings) error {
+	hosts := []*pgconn.FallbackConfig{{Host: config.Host, Port: config.Port}}
+	for _, fallback := range config.Fallbacks {
+		if last := hosts[len(hosts)-1]; fallback.Host != last.Host || fallback.Port != last.Port {
+			hosts = append(hosts, &pgconn.FallbackConfig{Host: fallback.Host, Port: fallback.Port})
+		}
+	}
+
+	var attempts []*pgconn.FallbackConfig
+	for _, host := range hosts {
+		tlsConfigs := []*tls.Config{nil}
+		if !strings.HasPrefix(host.Host, "/") {
+			var err error
+			if tlsConfigs, err = settings.TLSConfigs(host.Host); err != nil {
+				return err
+			}
+		}
+
+		for _, tlsConfig := range tlsConfigs {
+			attempts = append(attempts, &pgconn.FallbackConfig{Host: host.Host, Port: host.Port, TLSConfig: tlsConfig})
+		}
+	}
+
+	config.Host, config.Port, config.TLSConfig = attempts[0].Host, attempts[0].Port, attempts[0].TLSConfig
+	config.Fallbacks = attempts[1:]
+
+	return nil
+}
+
+// TLSConfigs returns the TLS configurations to try in turn when connecting to host, where nil means an unencrypted
+// connection, following the semantics of libpq's sslmode. An empty sslmode means `prefer`, and like with libpq,
+// `require` verifies the server certificate as `verify-ca` does when a root certificate is set.
+func (s TLSSettings) TLSConfigs(host string) ([]*tls.Config, error) {
+	sslMode := s.SSLMode
+	if sslMode == "" {
+		sslMode = SSLModePrefer
+	}
+	if sslMode == SSLModeDisable {
+		return []*tls.Config{nil}, nil
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if s.SSLRootCert == "system" {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the system certificate pool: %w", err)
+		}
+		tlsConfig.RootCAs = rootCAs
+		sslMode = SSLModeVerifyFull
+	} else if s.SSLRootCert != "" {
+		rootCert, err := readPEM(s.SSLRootCert)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read sslrootcert: %w", err)
+		}
+
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(rootCert) {
+			return nil, errors.New("unable to read sslrootcert: no PEM certificate found")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	switch sslMode {
+	case SSLModeAllow, SSLModePrefer:
+		tlsConfig.InsecureSkipVerify = true
+	case SSLModeRequire, SSLModeVerifyCA:
+		// The server name isn't verified, so the default verification is replaced with a verification of the chain
+		// only.
+		tlsConfig.InsecureSkipVerify = true
+		if sslMode == SSLModeVerifyCA || tlsConfig.RootCAs != nil {
+			if tlsConfig.RootCAs == nil {
+				return nil, fmt.Errorf("sslmode %s requires sslrootcert", sslMode)
+			}
+			tlsConfig.VerifyPeerCertificate = verifyChain(tlsConfig.RootCAs)
+		}
+	case SSLModeVerifyFull:
+		tlsConfig.ServerName = host
+	default:
+		return nil, fmt.Errorf("invalid sslmode %q, expected one of %s", sslMode, strings.Join(SSLModes, ", "))
+	}
+
+	if (s.SSLCert == "") != (s.SSLKey == "") {
+		return nil, errors.New("sslcert and sslkey must be set together")
+	}
+
+	if s.SSLCert != "" {
+		certificate, err := s.clientCertificate()
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	// Server Name Indication must not be sent for IP addresses (RFC 6066).
+	if s.SSLSNI && net.ParseIP(host) == nil {
+		tlsConfig.ServerName = host
+	}
+
+	switch sslMode {
+	case SSLModeAllow:
+		return []*tls.Config{nil, tlsConfig}, nil
+	case SSLModePrefer:
+		return []*tls.Config{tlsConfig, nil}, nil
+	default:
+		return []*tls.Config{tlsConfig}, nil
+	}
+}
+
+// clientCertificate loads the client certificate, decrypting its key with sslpassword when the key is encrypted.
+func (s TLSSettings) clientCertificate() (tls.Certificate, error) {
+	certPEM, err := readPEM(s.SSLCert)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("unable to read sslcert: %w", err)
+	}
+
+	keyPEM, err := readPEM(s.SSLKey)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("unable to read sslkey: %w", err)
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return tls.Certificate{}, errors.New("unable to read sslkey: no PEM key found")
+	}
+
+	// Like libpq, only keys encrypted with the legacy PEM encryption are supported.
+	//nolint:staticcheck // The legacy PEM encryption is insecure, but it's still what OpenSSL-based clients produce.
+	if x509.IsEncryptedPEMBlock(keyBlock) {
+		if s.SSLPassword == "" {
+			return tls.Certificate{}, errors.New("sslkey is encrypted, but no sslpassword is set")
+		}
+
+		//nolint:staticcheck // See above.
+		decryptedKey, err := x509.DecryptPEMBlock(keyBlock, []byte(s.SSLPassword))
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf("unable to decrypt sslkey: %w", err)
+		}
+
+		keyPEM = pem.EncodeToMemory(&pem.Block{Type: keyBlock.Type, Bytes: decryptedKey})
+	}
+
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("unable to load the client certificate: %w", err)
+	}
+
+	return certificate, nil
+}
+
+// readPEM returns value when it's PEM content, and otherwise reads the file at path value.
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN ") {
+		return []byte(value), nil
+	}
+
+	return os.ReadFile(value)
+}
+
+// verifyChain returns a function verifying that the server's certificate chain is signed by one of rootCAs, without
+// verifying the server name, like libpq's verify-ca.
+func verifyChain(rootCAs *x509.CertPool) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("the server didn't present a certificate")
+		}
+
+		certificates := make([]*x509.Certificate, len(rawCerts))
+		for i, rawCert := range rawCerts {
+			certificate, err := x509.ParseCertificate(rawCert)
+			if err != nil {
+				return fmt.Errorf("unable to parse the server certificate: %w", err)
+			}
+			certificates[i] = certificate
+		}
+
+		options := x509.VerifyOptions{
+			Roots:         rootCAs,
+			Intermediates: x509.NewCertPool(),
+		}
+		for _, intermediate := range certificates[1:] {
+			options.Intermediates.AddCert(intermediate)
+		}
+
+		_, err := certificates[0].Verify(options)
+		return err
+	}
+}
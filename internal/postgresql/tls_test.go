@@ -0,0 +1,395 @@
+package postgresql
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	certPEM     string
+	keyPEM      string
+}
+
+func newTestCertificate(t *testing.T, template *x509.Certificate, issuer *testCertificate) testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	parent, parentKey := template, key
+	if issuer != nil {
+		parent, parentKey = issuer.certificate, issuer.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return testCertificate{
+		certificate: certificate,
+		key:         key,
+		certPEM:     string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPEM:      string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}
+}
+
+func newTestCA(t *testing.T) testCertificate {
+	return newTestCertificate(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+}
+
+// handshake runs a TLS handshake between a client using clientConfig and a server presenting serverCert, and returns
+// the client's error and the certificates the client presented.
+func handshake(t *testing.T, clientConfig *tls.Config, serverCert testCertificate, clientCAs *x509.CertPool) ([]*x509.Certificate, error) {
+	t.Helper()
+
+	serverKeyPair, err := tls.X509KeyPair([]byte(serverCert.certPEM), []byte(serverCert.keyPEM))
+	require.NoError(t, err)
+
+	// Loopback TCP connections are buffered, unlike net.Pipe, so that a failed handshake doesn't block either side.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	serverConfig := &tls.Config{Certificates: []tls.Certificate{serverKeyPair}}
+	if clientCAs != nil {
+		serverConfig.ClientCAs = clientCAs
+		serverConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	serverDone := make(chan []*x509.Certificate)
+	go func() {
+		serverConn, err := listener.Accept()
+		if err != nil {
+			serverDone <- nil
+			return
+		}
+		defer serverConn.Close()
+
+		server := tls.Server(serverConn, serverConfig)
+		_ = server.Handshake()
+		serverDone <- server.ConnectionState().PeerCertificates
+	}()
+
+	clientConn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+
+	err = tls.Client(clientConn, clientConfig).Handshake()
+	_ = clientConn.Close()
+
+	return <-serverDone, err
+}
+
+func TestTLSConfigs(t *testing.T) {
+	t.Parallel()
+
+	ca := newTestCA(t)
+	otherCA := newTestCA(t)
+	serverCert := newTestCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "localhost"},
+		DNSNames:    []string{"localhost"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, &ca)
+
+	testCases := []struct {
+		testName            string
+		settings            TLSSettings
+		host                string
+		expectedAttempts    int
+		expectedTLSAttempt  int
+		expectedHandshakeOK bool
+		expectedServerName  string
+	}{
+		{
+			testName:            "Prefer by default",
+			settings:            TLSSettings{SSLSNI: true},
+			host:                "localhost",
+			expectedAttempts:    2,
+			expectedTLSAttempt:  0,
+			expectedHandshakeOK: true,
+			expectedServerName:  "localhost",
+		},
+		{
+			testName:            "Allow",
+			settings:            TLSSettings{SSLMode: SSLModeAllow},
+			host:                "localhost",
+			expectedAttempts:    2,
+			expectedTLSAttempt:  1,
+			expectedHandshakeOK: true,
+		},
+		{
+			testName:            "Require without root certificate",
+			settings:            TLSSettings{SSLMode: SSLModeRequire},
+			host:                "localhost",
+			expectedAttempts:    1,
+			expectedHandshakeOK: true,
+		},
+		{
+			testName:            "Require with the wrong root certificate",
+			settings:            TLSSettings{SSLMode: SSLModeRequire, SSLRootCert: otherCA.certPEM},
+			host:                "localhost",
+			expectedAttempts:    1,
+			expectedHandshakeOK: false,
+		},
+		{
+			testName:            "Verify CA with another host name",
+			settings:            TLSSettings{SSLMode: SSLModeVerifyCA, SSLRootCert: ca.certPEM},
+			host:                "127.0.0.1",
+			expectedAttempts:    1,
+			expectedHandshakeOK: true,
+		},
+		{
+			testName:            "Verify CA with the wrong root certificate",
+			settings:            TLSSettings{SSLMode: SSLModeVerifyCA, SSLRootCert: otherCA.certPEM},
+			host:                "localhost",
+			expectedAttempts:    1,
+			expectedHandshakeOK: false,
+		},
+		{
+			testName:            "Verify full",
+			settings:            TLSSettings{SSLMode: SSLModeVerifyFull, SSLRootCert: ca.certPEM},
+			host:                "localhost",
+			expectedAttempts:    1,
+			expectedHandshakeOK: true,
+			expectedServerName:  "localhost",
+		},
+		{
+			testName:            "Verify full with another host name",
+			settings:            TLSSettings{SSLMode: SSLModeVerifyFull, SSLRootCert: ca.certPEM},
+			host:                "127.0.0.1",
+			expectedAttempts:    1,
+			expectedHandshakeOK: false,
+			expectedServerName:  "127.0.0.1",
+		},
+		{
+			testName:            "No SNI for IP addresses",
+			settings:            TLSSettings{SSLMode: SSLModeRequire, SSLSNI: true},
+			host:                "127.0.0.1",
+			expectedAttempts:    1,
+			expectedHandshakeOK: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			tlsConfigs, err := testCase.settings.TLSConfigs(testCase.host)
+			require.NoError(t, err)
+			require.Len(t, tlsConfigs, testCase.expectedAttempts)
+
+			for i, tlsConfig := range tlsConfigs {
+				if i != testCase.expectedTLSAttempt {
+					assert.Nil(t, tlsConfig)
+				}
+			}
+
+			tlsConfig := tlsConfigs[testCase.expectedTLSAttempt]
+			require.NotNil(t, tlsConfig)
+			assert.Equal(t, testCase.expectedServerName, tlsConfig.ServerName)
+
+			_, err = handshake(t, tlsConfig, serverCert, nil)
+			if testCase.expectedHandshakeOK {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestTLSConfigsDisable(t *testing.T) {
+	t.Parallel()
+
+	tlsConfigs, err := TLSSettings{SSLMode: SSLModeDisable}.TLSConfigs("localhost")
+	require.NoError(t, err)
+	assert.Equal(t, []*tls.Config{nil}, tlsConfigs)
+}
+
+func TestTLSConfigsErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName string
+		settings TLSSettings
+	}{
+		{
+			testName: "Invalid sslmode",
+			settings: TLSSettings{SSLMode: "always"},
+		},
+		{
+			testName: "Verify CA without root certificate",
+			settings: TLSSettings{SSLMode: SSLModeVerifyCA},
+		},
+		{
+			testName: "Missing root certificate file",
+			settings: TLSSettings{SSLMode: SSLModeVerifyFull, SSLRootCert: filepath.Join(t.TempDir(), "missing.crt")},
+		},
+		{
+			testName: "Root certificate without certificate",
+			settings: TLSSettings{SSLMode: SSLModeVerifyFull, SSLRootCert: "-----BEGIN nothing"},
+		},
+		{
+			testName: "Client certificate without key",
+			settings: TLSSettings{SSLMode: SSLModeRequire, SSLCert: "client.crt"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := testCase.settings.TLSConfigs("localhost")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTLSConfigsClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	ca := newTestCA(t)
+	serverCert := newTestCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "localhost"},
+		DNSNames:    []string{"localhost"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, &ca)
+	clientCert := newTestCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "tls_client"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, &ca)
+
+	// Keys encrypted by OpenSSL with the legacy PEM encryption are RSA keys.
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaCertDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "tls_client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca.certificate, &rsaKey.PublicKey, ca.key)
+	require.NoError(t, err)
+	//nolint:staticcheck // Legacy PEM encryption is what's being tested.
+	encryptedKeyBlock, err := x509.EncryptPEMBlock(rand.Reader, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey), []byte("secret"), x509.PEMCipherAES256)
+	require.NoError(t, err)
+	rsaCertPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rsaCertDER}))
+	encryptedKeyPEM := string(pem.EncodeToMemory(encryptedKeyBlock))
+
+	directory := t.TempDir()
+	certPath := filepath.Join(directory, "client.crt")
+	keyPath := filepath.Join(directory, "client.key")
+	require.NoError(t, os.WriteFile(certPath, []byte(clientCert.certPEM), 0o600))
+	require.NoError(t, os.WriteFile(keyPath, []byte(clientCert.keyPEM), 0o600))
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.certificate)
+
+	testCases := []struct {
+		testName       string
+		settings       TLSSettings
+		expectedErrMsg string
+	}{
+		{
+			testName: "Paths",
+			settings: TLSSettings{SSLCert: certPath, SSLKey: keyPath},
+		},
+		{
+			testName: "PEM content",
+			settings: TLSSettings{SSLCert: clientCert.certPEM, SSLKey: clientCert.keyPEM},
+		},
+		{
+			testName: "Encrypted key",
+			settings: TLSSettings{SSLCert: rsaCertPEM, SSLKey: encryptedKeyPEM, SSLPassword: "secret"},
+		},
+		{
+			testName:       "Encrypted key without password",
+			settings:       TLSSettings{SSLCert: rsaCertPEM, SSLKey: encryptedKeyPEM},
+			expectedErrMsg: "no sslpassword is set",
+		},
+		{
+			testName:       "Encrypted key with the wrong password",
+			settings:       TLSSettings{SSLCert: rsaCertPEM, SSLKey: encryptedKeyPEM, SSLPassword: "wrong"},
+			expectedErrMsg: "unable to decrypt sslkey",
+		},
+		{
+			testName:       "Mismatched key",
+			settings:       TLSSettings{SSLCert: rsaCertPEM, SSLKey: clientCert.keyPEM},
+			expectedErrMsg: "unable to load the client certificate",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			settings := testCase.settings
+			settings.SSLMode = SSLModeVerifyFull
+			settings.SSLRootCert = ca.certPEM
+
+			tlsConfigs, err := settings.TLSConfigs("localhost")
+			if testCase.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, testCase.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			peerCertificates, err := handshake(t, tlsConfigs[0], serverCert, clientCAs)
+			require.NoError(t, err)
+			require.Len(t, peerCertificates, 1)
+			assert.Equal(t, "tls_client", peerCertificates[0].Subject.CommonName)
+		})
+	}
+}
+
+func TestApplyTLSSettings(t *testing.T) {
+	t.Parallel()
+
+	config, err := pgconn.ParseConfig("host=db1.example.com,/var/run/postgresql port=5432,5433 user=terraform dbname=terraform_test sslmode=disable")
+	require.NoError(t, err)
+
+	err = ApplyTLSSettings(config, TLSSettings{SSLMode: SSLModePrefer, SSLSNI: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "db1.example.com", config.Host)
+	require.NotNil(t, config.TLSConfig)
+	assert.Equal(t, "db1.example.com", config.TLSConfig.ServerName)
+
+	require.Len(t, config.Fallbacks, 2)
+	assert.Equal(t, "db1.example.com", config.Fallbacks[0].Host)
+	assert.Nil(t, config.Fallbacks[0].TLSConfig)
+
+	// Unix sockets are never encrypted.
+	assert.Equal(t, "/var/run/postgresql", config.Fallbacks[1].Host)
+	assert.Equal(t, uint16(5433), config.Fallbacks[1].Port)
+	assert.Nil(t, config.Fallbacks[1].TLSConfig)
+}
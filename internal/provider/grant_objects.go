@@ -0,0 +1,348 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/ktham/terraform-provider-postgresql/internal/postgresql/pgsql"
+	"slices"
+	"strings"
+)
+
+// grantObjectType describes how privileges on one kind of database object are granted, and where the resulting
+// access privileges are stored in the system catalogs.
+type grantObjectType struct {
+	// keyword is the object kind used in `GRANT ... ON <keyword> <object>`.
+	keyword string
+	// allInSchemaKeyword is the object kind used in `GRANT ... ON ALL <allInSchemaKeyword> IN SCHEMA <schema>`. It's
+	// empty when the object type doesn't support granting on every object of a schema at once.
+	allInSchemaKeyword string
+	// inSchema reports whether objects of this type live in a schema.
+	inSchema bool
+	// privileges lists every privilege that can be granted on objects of this type.
+	privileges []string
+	// resolveQuery looks up the OID and the fully qualified, quoted name of objects of this type. Schema scoped object
+	// types take the schema name as $1 and an array of object names as $2, where a NULL array means every object of the
+	// schema. Other object types take an array of object names as $1.
+	resolveQuery string
+	// catalog, aclColumn and ownerColumn locate the access privileges of objects of this type in the system catalogs.
+	catalog     string
+	aclColumn   string
+	ownerColumn string
+	// aclDefaultKind is the object type code passed to acldefault() to compute the privileges of objects whose ACL is
+	// still NULL.
+	aclDefaultKind string
+}
+
+const resolveRoutinesQuery = `
+SELECT
+    p.oid,
+    format('%%I.%%I(%%s)', n.nspname, p.proname, pg_get_function_identity_arguments(p.oid))
+FROM
+    pg_proc p
+    JOIN pg_namespace n ON n.oid = p.pronamespace
+WHERE
+    n.nspname = $1
+    AND p.prokind IN (%s)
+    AND (
+        $2::text[] IS NULL
+        OR p.proname = ANY($2)
+        OR p.oid = ANY(SELECT to_regprocedure(format('%%I.', $1::text) || o) FROM unnest($2::text[]) o WHERE strpos(o, '(') > 0)
+    );`
+
+const resolveRelationsQuery = `
+SELECT
+    c.oid,
+    format('%%I.%%I', n.nspname, c.relname)
+FROM
+    pg_class c
+    JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE
+    n.nspname = $1
+    AND c.relkind IN (%s)
+    AND ($2::text[] IS NULL OR c.relname = ANY($2));`
+
+const resolveTypesQuery = `
+SELECT
+    t.oid,
+    format('%%I.%%I', n.nspname, t.typname)
+FROM
+    pg_type t
+    JOIN pg_namespace n ON n.oid = t.typnamespace
+WHERE
+    n.nspname = $1
+    AND t.typtype %s
+    AND t.typname = ANY($2);`
+
+var grantObjectTypes = map[string]grantObjectType{
+	"table": {
+		keyword:            "TABLE",
+		allInSchemaKeyword: "TABLES",
+		inSchema:           true,
+		privileges:         []string{"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER", "MAINTAIN"},
+		resolveQuery:       fmt.Sprintf(resolveRelationsQuery, "'r', 'v', 'm', 'f', 'p'"),
+		catalog:            "pg_class",
+		aclColumn:          "relacl",
+		ownerColumn:        "relowner",
+		aclDefaultKind:     "r",
+	},
+	"sequence": {
+		keyword:            "SEQUENCE",
+		allInSchemaKeyword: "SEQUENCES",
+		inSchema:           true,
+		privileges:         []string{"USAGE", "SELECT", "UPDATE"},
+		resolveQuery:       fmt.Sprintf(resolveRelationsQuery, "'S'"),
+		catalog:            "pg_class",
+		aclColumn:          "relacl",
+		ownerColumn:        "relowner",
+		aclDefaultKind:     "s",
+	},
+	"function": {
+		keyword:            "FUNCTION",
+		allInSchemaKeyword: "FUNCTIONS",
+		inSchema:           true,
+		privileges:         []string{"EXECUTE"},
+		resolveQuery:       fmt.Sprintf(resolveRoutinesQuery, "'f', 'a', 'w'"),
+		catalog:            "pg_proc",
+		aclColumn:          "proacl",
+		ownerColumn:        "proowner",
+		aclDefaultKind:     "f",
+	},
+	"procedure": {
+		keyword:            "PROCEDURE",
+		allInSchemaKeyword: "PROCEDURES",
+		inSchema:           true,
+		privileges:         []string{"EXECUTE"},
+		resolveQuery:       fmt.Sprintf(resolveRoutinesQuery, "'p'"),
+		catalog:            "pg_proc",
+		aclColumn:          "proacl",
+		ownerColumn:        "proowner",
+		aclDefaultKind:     "f",
+	},
+	"routine": {
+		keyword:            "ROUTINE",
+		allInSchemaKeyword: "ROUTINES",
+		inSchema:           true,
+		privileges:         []string{"EXECUTE"},
+		resolveQuery:       fmt.Sprintf(resolveRoutinesQuery, "'f', 'a', 'w', 'p'"),
+		catalog:            "pg_proc",
+		aclColumn:          "proacl",
+		ownerColumn:        "proowner",
+		aclDefaultKind:     "f",
+	},
+	"schema": {
+		keyword:        "SCHEMA",
+		privileges:     []string{"CREATE", "USAGE"},
+		resolveQuery:   "SELECT oid, format('%I', nspname) FROM pg_namespace WHERE nspname = ANY($1);",
+		catalog:        "pg_namespace",
+		aclColumn:      "nspacl",
+		ownerColumn:    "nspowner",
+		aclDefaultKind: "n",
+	},
+	"database": {
+		keyword:        "DATABASE",
+		privileges:     []string{"CREATE", "CONNECT", "TEMPORARY"},
+		resolveQuery:   "SELECT oid, format('%I', datname) FROM pg_database WHERE datname = ANY($1);",
+		catalog:        "pg_database",
+		aclColumn:      "datacl",
+		ownerColumn:    "datdba",
+		aclDefaultKind: "d",
+	},
+	"foreign_data_wrapper": {
+		keyword:        "FOREIGN DATA WRAPPER",
+		privileges:     []string{"USAGE"},
+		resolveQuery:   "SELECT oid, format('%I', fdwname) FROM pg_foreign_data_wrapper WHERE fdwname = ANY($1);",
+		catalog:        "pg_foreign_data_wrapper",
+		aclColumn:      "fdwacl",
+		ownerColumn:    "fdwowner",
+		aclDefaultKind: "F",
+	},
+	"foreign_server": {
+		keyword:        "FOREIGN SERVER",
+		privileges:     []string{"USAGE"},
+		resolveQuery:   "SELECT oid, format('%I', srvname) FROM pg_foreign_server WHERE srvname = ANY($1);",
+		catalog:        "pg_foreign_server",
+		aclColumn:      "srvacl",
+		ownerColumn:    "srvowner",
+		aclDefaultKind: "S",
+	},
+	"type": {
+		keyword:        "TYPE",
+		inSchema:       true,
+		privileges:     []string{"USAGE"},
+		resolveQuery:   fmt.Sprintf(resolveTypesQuery, "<> 'd'"),
+		catalog:        "pg_type",
+		aclColumn:      "typacl",
+		ownerColumn:    "typowner",
+		aclDefaultKind: "T",
+	},
+	"domain": {
+		keyword:        "DOMAIN",
+		inSchema:       true,
+		privileges:     []string{"USAGE"},
+		resolveQuery:   fmt.Sprintf(resolveTypesQuery, "= 'd'"),
+		catalog:        "pg_type",
+		aclColumn:      "typacl",
+		ownerColumn:    "typowner",
+		aclDefaultKind: "T",
+	},
+	"large_object": {
+		keyword:        "LARGE OBJECT",
+		privileges:     []string{"SELECT", "UPDATE"},
+		resolveQuery:   "SELECT oid, oid::text FROM pg_largeobject_metadata WHERE oid::text = ANY($1);",
+		catalog:        "pg_largeobject_metadata",
+		aclColumn:      "lomacl",
+		ownerColumn:    "lomowner",
+		aclDefaultKind: "L",
+	},
+}
+
+// grantObjectTypeNames returns the names of every supported object type, sorted alphabetically.
+func grantObjectTypeNames() []string {
+	names := make([]string, 0, len(grantObjectTypes))
+	for name := range grantObjectTypes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// aclQuery returns a query listing the privileges granted to the role with OID $2 on the objects of this type whose
+// OIDs are in the array $1.
+func (t grantObjectType) aclQuery() string {
+	return fmt.Sprintf(`
+SELECT
+    o.oid,
+    a.privilege_type,
+    a.is_grantable
+FROM
+    %[1]s o
+    CROSS JOIN LATERAL aclexplode(coalesce(o.%[2]s, acldefault('%[4]s', o.%[3]s))) a
+WHERE
+    o.oid = ANY($1)
+    AND a.grantee = $2;`, t.catalog, t.aclColumn, t.ownerColumn, t.aclDefaultKind)
+}
+
+// grantOnClause returns the `ON ...` clause of a GRANT or REVOKE statement for the given, already quoted, object
+// names. When allInSchema is true, the clause targets every object of this type in schema instead.
+func (t grantObjectType) grantOnClause(schema string, quotedObjects []string, allInSchema bool) string {
+	if allInSchema {
+		return fmt.Sprintf("ON ALL %s IN SCHEMA %s", t.allInSchemaKeyword, pgsql.Identifier(schema))
+	}
+
+	return fmt.Sprintf("ON %s %s", t.keyword, strings.Join(quotedObjects, ", "))
+}
+
+// buildGrantStatement returns a GRANT statement giving role the privileges on the objects described by onClause.
+func buildGrantStatement(onClause string, role string, privileges []string, withGrantOption bool) string {
+	grantSql := fmt.Sprintf("GRANT %s %s TO %s", strings.Join(privileges, ", "), onClause, pgsql.Grantee(role))
+
+	if withGrantOption {
+		grantSql += " WITH GRANT OPTION"
+	}
+
+	return grantSql + ";"
+}
+
+// grantorsQuery returns a query listing the grants of the privileges in the array $3 to the role with OID $2 on the
+// objects of this type whose OIDs are in the array $1, along with whether they're held with the grant option and the
+// name of their grantor.
+func (t grantObjectType) grantorsQuery() string {
+	return fmt.Sprintf(`
+SELECT DISTINCT
+    a.privilege_type,
+    a.is_grantable,
+    pg_get_userbyid(a.grantor)
+FROM
+    %[1]s o
+    CROSS JOIN LATERAL aclexplode(coalesce(o.%[2]s, acldefault('%[4]s', o.%[3]s))) a
+WHERE
+    o.oid = ANY($1)
+    AND a.grantee = $2
+    AND a.privilege_type = ANY($3)
+ORDER BY
+    1, 3;`, t.catalog, t.aclColumn, t.ownerColumn, t.aclDefaultKind)
+}
+
+// grantChanges are the changes turning the privileges a role holds on some objects into the privileges of a grant.
+type grantChanges struct {
+	// grant lists the privileges to grant, with the grant option when grantWithGrantOption is true.
+	grant                []string
+	grantWithGrantOption bool
+	// revoke lists the privileges to revoke.
+	revoke []string
+	// revokeGrantOption lists the privileges whose grant option is revoked, while they're still held.
+	revokeGrantOption []string
+}
+
+// diffGrant returns the changes from the prior privileges, held with the grant option when priorWithGrantOption is
+// true, to the planned ones. Privileges held both before and after aren't granted again, unless the grant option is
+// turned on, so that the privileges the role granted on to other roles are left alone.
+func diffGrant(prior []string, priorWithGrantOption bool, planned []string, withGrantOption bool) grantChanges {
+	var changes grantChanges
+	var kept []string
+
+	for _, privilege := range planned {
+		if slices.Contains(prior, privilege) {
+			kept = append(kept, privilege)
+		} else {
+			changes.grant = append(changes.grant, privilege)
+		}
+	}
+	for _, privilege := range prior {
+		if !slices.Contains(planned, privilege) {
+			changes.revoke = append(changes.revoke, privilege)
+		}
+	}
+
+	changes.grantWithGrantOption = withGrantOption
+	if withGrantOption && !priorWithGrantOption {
+		changes.grant = planned
+	}
+	if !withGrantOption && priorWithGrantOption {
+		changes.revokeGrantOption = kept
+	}
+
+	return changes
+}
+
+// statements returns the REVOKE and GRANT statements applying the changes to role on the objects described by
+// onClause. With cascade, revoking also revokes what role granted on to other roles with the grant option.
+func (c grantChanges) statements(onClause string, role string, cascade bool) []string {
+	var statements []string
+
+	if len(c.revoke) > 0 {
+		statements = append(statements, buildRevokeStatement(onClause, role, c.revoke, false, cascade))
+	}
+	if len(c.revokeGrantOption) > 0 {
+		statements = append(statements, buildRevokeStatement(onClause, role, c.revokeGrantOption, true, cascade))
+	}
+	if len(c.grant) > 0 {
+		statements = append(statements, buildGrantStatement(onClause, role, c.grant, c.grantWithGrantOption))
+	}
+
+	return statements
+}
+
+// buildRevokeStatement returns a REVOKE statement removing the privileges role holds on the objects described by
+// onClause, or only their grant option when grantOptionOnly is true. With cascade, the privileges role granted on to
+// other roles are revoked as well.
+func buildRevokeStatement(onClause string, role string, privileges []string, grantOptionOnly bool, cascade bool) string {
+	revokeSql := "REVOKE "
+	if grantOptionOnly {
+		revokeSql += "GRANT OPTION FOR "
+	}
+
+	revokeSql += fmt.Sprintf("%s %s FROM %s", strings.Join(privileges, ", "), onClause, pgsql.Grantee(role))
+
+	if cascade {
+		revokeSql += " CASCADE"
+	}
+
+	return revokeSql + ";"
+}
+
+// buildRevokeAllStatement returns a REVOKE statement removing every privilege role holds on the objects described by
+// onClause.
+func buildRevokeAllStatement(onClause string, role string) string {
+	return fmt.Sprintf("REVOKE ALL PRIVILEGES %s FROM %s;", onClause, pgsql.Grantee(role))
+}
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildGrantStatement(t *testing.T) {
+	testCases := []struct {
+		testName        string
+		objectType      string
+		schema          string
+		objects         []string
+		allInSchema     bool
+		role            string
+		privileges      []string
+		withGrantOption bool
+		expectedOutput  string
+	}{
+		{
+			testName:       "Tables by name",
+			objectType:     "table",
+			schema:         "app",
+			objects:        []string{`"app"."users"`, `"app"."orders"`},
+			role:           "reader",
+			privileges:     []string{"SELECT"},
+			expectedOutput: `GRANT SELECT ON TABLE "app"."users", "app"."orders" TO "reader";`,
+		},
+		{
+			testName:        "Every sequence in schema with grant option",
+			objectType:      "sequence",
+			schema:          "app",
+			allInSchema:     true,
+			role:            "writer",
+			privileges:      []string{"SELECT", "USAGE"},
+			withGrantOption: true,
+			expectedOutput:  `GRANT SELECT, USAGE ON ALL SEQUENCES IN SCHEMA "app" TO "writer" WITH GRANT OPTION;`,
+		},
+		{
+			testName:       "Foreign server to PUBLIC",
+			objectType:     "foreign_server",
+			objects:        []string{`"remote"`},
+			role:           "public",
+			privileges:     []string{"USAGE"},
+			expectedOutput: `GRANT USAGE ON FOREIGN SERVER "remote" TO PUBLIC;`,
+		},
+		{
+			testName:       "Role name requiring quoting",
+			objectType:     "schema",
+			objects:        []string{`"App"`},
+			role:           `Team "A"`,
+			privileges:     []string{"USAGE"},
+			expectedOutput: `GRANT USAGE ON SCHEMA "App" TO "Team ""A""";`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			onClause := grantObjectTypes[testCase.objectType].grantOnClause(testCase.schema, testCase.objects, testCase.allInSchema)
+			actualOutput := buildGrantStatement(onClause, testCase.role, testCase.privileges, testCase.withGrantOption)
+
+			assert.Equal(t, testCase.expectedOutput, actualOutput)
+		})
+	}
+}
+
+func TestBuildRevokeAllStatement(t *testing.T) {
+	onClause := grantObjectTypes["function"].grantOnClause("app", nil, true)
+
+	assert.Equal(t, `REVOKE ALL PRIVILEGES ON ALL FUNCTIONS IN SCHEMA "app" FROM "reader";`, buildRevokeAllStatement(onClause, "reader"))
+}
+
+func TestGrantChangesStatements(t *testing.T) {
+	testCases := []struct {
+		testName             string
+		prior                []string
+		priorWithGrantOption bool
+		planned              []string
+		withGrantOption      bool
+		cascade              bool
+		expectedOutput       []string
+	}{
+		{
+			testName: "No changes",
+			prior:    []string{"SELECT"},
+			planned:  []string{"SELECT"},
+		},
+		{
+			testName:       "Added privilege",
+			prior:          []string{"SELECT"},
+			planned:        []string{"INSERT", "SELECT"},
+			expectedOutput: []string{`GRANT INSERT ON TABLE "app"."users" TO "reader";`},
+		},
+		{
+			testName:             "Added privilege with grant option",
+			prior:                []string{"SELECT"},
+			priorWithGrantOption: true,
+			planned:              []string{"INSERT", "SELECT"},
+			withGrantOption:      true,
+			expectedOutput:       []string{`GRANT INSERT ON TABLE "app"."users" TO "reader" WITH GRANT OPTION;`},
+		},
+		{
+			testName:       "Removed privilege",
+			prior:          []string{"INSERT", "SELECT"},
+			planned:        []string{"SELECT"},
+			expectedOutput: []string{`REVOKE INSERT ON TABLE "app"."users" FROM "reader";`},
+		},
+		{
+			testName:       "Removed privilege with cascade",
+			prior:          []string{"INSERT", "SELECT"},
+			planned:        []string{},
+			cascade:        true,
+			expectedOutput: []string{`REVOKE INSERT, SELECT ON TABLE "app"."users" FROM "reader" CASCADE;`},
+		},
+		{
+			testName:        "Grant option turned on",
+			prior:           []string{"SELECT"},
+			planned:         []string{"INSERT", "SELECT"},
+			withGrantOption: true,
+			expectedOutput:  []string{`GRANT INSERT, SELECT ON TABLE "app"."users" TO "reader" WITH GRANT OPTION;`},
+		},
+		{
+			testName:             "Grant option turned off",
+			prior:                []string{"INSERT", "SELECT"},
+			priorWithGrantOption: true,
+			planned:              []string{"SELECT", "UPDATE"},
+			expectedOutput: []string{
+				`REVOKE INSERT ON TABLE "app"."users" FROM "reader";`,
+				`REVOKE GRANT OPTION FOR SELECT ON TABLE "app"."users" FROM "reader";`,
+				`GRANT UPDATE ON TABLE "app"."users" TO "reader";`,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			changes := diffGrant(testCase.prior, testCase.priorWithGrantOption, testCase.planned, testCase.withGrantOption)
+			actualOutput := changes.statements(`ON TABLE "app"."users"`, "reader", testCase.cascade)
+
+			assert.Equal(t, testCase.expectedOutput, actualOutput)
+		})
+	}
+}
+
+func TestBuildAlterDefaultPrivilegesStatement(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		schema         string
+		expectedOutput string
+	}{
+		{
+			testName:       "Global default privileges",
+			expectedOutput: `ALTER DEFAULT PRIVILEGES FOR ROLE "migrator" GRANT SELECT ON TABLES TO "reader";`,
+		},
+		{
+			testName:       "Per-schema default privileges",
+			schema:         "app",
+			expectedOutput: `ALTER DEFAULT PRIVILEGES FOR ROLE "migrator" IN SCHEMA "app" GRANT SELECT ON TABLES TO "reader";`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			grantSql := buildGrantStatement("ON TABLES", "reader", []string{"SELECT"}, false)
+			actualOutput := buildAlterDefaultPrivilegesStatement("migrator", testCase.schema, grantSql)
+
+			assert.Equal(t, testCase.expectedOutput, actualOutput)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"strings"
+)
+
+// listQuery builds the query of a list resource from the conditions of its filters.
+type listQuery struct {
+	conditions []string
+	args       []any
+}
+
+// where adds a condition without arguments.
+func (q *listQuery) where(condition string) {
+	q.conditions = append(q.conditions, condition)
+}
+
+// whereArg adds a condition comparing an expression to arg, e.g. `rolname LIKE` and a pattern.
+func (q *listQuery) whereArg(expression string, arg any) {
+	q.args = append(q.args, arg)
+	q.conditions = append(q.conditions, fmt.Sprintf("%s $%d", expression, len(q.args)))
+}
+
+// build returns the query, selecting with selectSql the rows matching every condition, ordered by orderBy and limited
+// to limit rows unless limit is 0, along with the query's arguments.
+func (q *listQuery) build(selectSql string, orderBy string, limit int64) (string, []any) {
+	query := selectSql
+	args := append([]any{}, q.args...)
+
+	if len(q.conditions) > 0 {
+		query += " WHERE " + strings.Join(q.conditions, " AND ")
+	}
+
+	query += " ORDER BY " + orderBy
+
+	if limit > 0 {
+		args = append(args, limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	return query + ";", args
+}
+
+// listedObject is an object found by a list resource.
+type listedObject struct {
+	oid  uint32
+	name string
+}
+
+// queryListedObjects returns the objects found by a query returning their OID and name.
+func queryListedObjects(ctx context.Context, pool *pgxpool.Pool, query string, args []any) ([]listedObject, error) {
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing query '%s', got error: %w", query, err)
+	}
+
+	var objects []listedObject
+
+	var object listedObject
+	_, err = pgx.ForEachRow(rows, []any{&object.oid, &object.name}, func() error {
+		objects = append(objects, object)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error executing query '%s', got error: %w", query, err)
+	}
+
+	return objects, nil
+}
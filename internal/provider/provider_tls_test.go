@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testAccTLSCertsDir returns the directory holding the certificates generated by docker/postgres-tls/generate-certs.sh,
+// and skips the test when they haven't been generated.
+func testAccTLSCertsDir(t *testing.T) string {
+	certsDir := getEnv("DATABASE_TLS_CERTS_DIR", filepath.Join("..", "..", "docker", "postgres-tls", "certs"))
+	if _, err := os.Stat(filepath.Join(certsDir, "ca.crt")); err != nil {
+		t.Skipf("TLS certificates not found in %s, run docker/postgres-tls/generate-certs.sh to generate them", certsDir)
+	}
+
+	return certsDir
+}
+
+// testAccTLSProviderConfig returns the configuration of a provider connecting to the TLS-only Postgres server of the
+// postgres_tls service. tlsAttributes are added to the provider block as is.
+func testAccTLSProviderConfig(hostname string, username string, tlsAttributes string) string {
+	password := ""
+	if username == getEnv("DATABASE_USER", "terraform") {
+		password = fmt.Sprintf("password = %q", getEnv("DATABASE_PASSWORD", "not_a_real_password"))
+	}
+
+	return fmt.Sprintf(`provider "postgresql" {
+    hostname = %q
+    username = %q
+    %s
+    database_name = %q
+    port = %d
+    %s
+  }`, hostname, username, password, getEnv("DATABASE_NAME", "terraform_test"), getEnvAsInt("DATABASE_TLS_PORT", 15433), tlsAttributes)
+}
+
+func testAccTLSRoleConfig(name string) string {
+	return fmt.Sprintf(`
+resource "postgresql_role" "test" {
+  name = %q
+}
+`, name)
+}
+
+func TestAccProviderTLS(t *testing.T) {
+	certsDir := testAccTLSCertsDir(t)
+	rootCert := filepath.Join(certsDir, "ca.crt")
+	dbUser := getEnv("DATABASE_USER", "terraform")
+
+	testCases := []struct {
+		testName      string
+		hostname      string
+		tlsAttributes string
+		expectedError *regexp.Regexp
+	}{
+		{
+			testName:      "Disable is rejected by the server",
+			hostname:      "localhost",
+			tlsAttributes: `sslmode = "disable"`,
+			expectedError: regexp.MustCompile(`pg_hba.conf`),
+		},
+		{
+			testName:      "Prefer",
+			hostname:      "localhost",
+			tlsAttributes: ``,
+		},
+		{
+			testName:      "Require",
+			hostname:      "localhost",
+			tlsAttributes: `sslmode = "require"`,
+		},
+		{
+			testName:      "Verify CA ignores the host name",
+			hostname:      "127.0.0.1",
+			tlsAttributes: fmt.Sprintf(`sslmode = "verify-ca"`+"\n"+`sslrootcert = %q`, rootCert),
+		},
+		{
+			testName:      "Verify full",
+			hostname:      "localhost",
+			tlsAttributes: fmt.Sprintf(`sslmode = "verify-full"`+"\n"+`sslrootcert = %q`, rootCert),
+		},
+		{
+			testName:      "Verify full checks the host name",
+			hostname:      "127.0.0.1",
+			tlsAttributes: fmt.Sprintf(`sslmode = "verify-full"`+"\n"+`sslrootcert = %q`, rootCert),
+			expectedError: regexp.MustCompile(`certificate`),
+		},
+	}
+
+	for i, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccTLSProviderConfig(testCase.hostname, dbUser, testCase.tlsAttributes) + testAccTLSRoleConfig(fmt.Sprintf("tls_role_%d", i)),
+						ExpectError: testCase.expectedError,
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccProviderTLSClientCertificate(t *testing.T) {
+	certsDir := testAccTLSCertsDir(t)
+
+	readFile := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(certsDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+
+	testCases := []struct {
+		testName      string
+		tlsAttributes string
+	}{
+		{
+			testName: "Paths",
+			tlsAttributes: fmt.Sprintf("sslmode = \"verify-full\"\nsslrootcert = %q\nsslcert = %q\nsslkey = %q",
+				filepath.Join(certsDir, "ca.crt"), filepath.Join(certsDir, "client.crt"), filepath.Join(certsDir, "client.key")),
+		},
+		{
+			testName: "PEM content",
+			tlsAttributes: fmt.Sprintf("sslmode = \"verify-full\"\nsslrootcert = %q\nsslcert = %q\nsslkey = %q",
+				readFile("ca.crt"), readFile("client.crt"), readFile("client.key")),
+		},
+		{
+			testName: "Encrypted key",
+			tlsAttributes: fmt.Sprintf("sslmode = \"verify-full\"\nsslrootcert = %q\nsslcert = %q\nsslkey = %q\nsslpassword = %q",
+				filepath.Join(certsDir, "ca.crt"), filepath.Join(certsDir, "client.crt"), filepath.Join(certsDir, "client-encrypted.key"),
+				"not_a_real_password"),
+		},
+	}
+
+	for i, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					// The tls_client role can only authenticate with its client certificate.
+					{
+						Config: testAccTLSProviderConfig("localhost", "tls_client", testCase.tlsAttributes) + testAccTLSRoleConfig(fmt.Sprintf("tls_client_role_%d", i)),
+						Check:  resource.TestCheckResourceAttr("postgresql_role.test", "name", fmt.Sprintf("tls_client_role_%d", i)),
+					},
+				},
+			})
+		})
+	}
+}
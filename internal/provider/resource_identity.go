@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"math"
+)
+
+// ServerObjectIdentityModel is the identity of an object shared by all databases of a server, such as a role or a
+// database. OIDs are only unique within a server, so the identity includes the server's system identifier.
+type ServerObjectIdentityModel struct {
+	SystemIdentifier types.String `tfsdk:"system_identifier"`
+	Oid              types.Int64  `tfsdk:"oid"`
+}
+
+// serverObjectIdentitySchema returns the identity schema of objects identified by ServerObjectIdentityModel.
+// objectKind is the kind of object, such as "role", used in the descriptions.
+func serverObjectIdentitySchema(objectKind string) identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"system_identifier": identityschema.StringAttribute{
+				Description: fmt.Sprintf("The system identifier of the Postgresql server of the %s, as returned by "+
+					"`SELECT system_identifier FROM pg_control_system();`. When importing, defaults to the server the "+
+					"provider is connected to.", objectKind),
+				OptionalForImport: true,
+			},
+			"oid": identityschema.Int64Attribute{
+				Description:       fmt.Sprintf("The object ID of the Postgresql %s.", objectKind),
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// serverObjectIdentity returns the identity of the object with the given OID on the server the provider is connected
+// to. The identity is only needed to import and list resources, so managing them doesn't depend on it: when the system
+// identifier can't be determined, e.g. because the provider's login may not execute pg_control_system(), it's left
+// null along with a warning. Such identities are imported from the server the provider is connected to.
+func (d PostgresqlProviderData) serverObjectIdentity(ctx context.Context, oid types.Int64) (ServerObjectIdentityModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	systemIdentifier, err := d.SystemIdentifier(ctx)
+	if err != nil {
+		diags.AddWarning(
+			"Incomplete resource identity",
+			fmt.Sprintf("Unable to determine the system identifier of the server, so it's left out of the resource identity, got error: %s", err),
+		)
+		return ServerObjectIdentityModel{SystemIdentifier: types.StringNull(), Oid: oid}, diags
+	}
+
+	return ServerObjectIdentityModel{SystemIdentifier: types.StringValue(systemIdentifier), Oid: oid}, diags
+}
+
+// setServerObjectIdentity sets identity to the identity of the object with the given OID, once the object exists. A
+// system identifier already in identity is kept rather than queried again, while a missing one is filled in once it
+// can be determined.
+func (d PostgresqlProviderData) setServerObjectIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, oid types.Int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !identity.Raw.IsFullyNull() {
+		var data ServerObjectIdentityModel
+		diags.Append(identity.Get(ctx, &data)...)
+
+		if diags.HasError() {
+			return diags
+		}
+
+		if !data.SystemIdentifier.IsNull() {
+			data.Oid = oid
+			diags.Append(identity.Set(ctx, data)...)
+			return diags
+		}
+	}
+
+	data, identityDiags := d.serverObjectIdentity(ctx, oid)
+	diags.Append(identityDiags...)
+	diags.Append(identity.Set(ctx, data)...)
+
+	return diags
+}
+
+// importedObjectOID returns the OID of the object identified by the identity of an import block, after checking that
+// the object belongs to the server the provider is connected to.
+func (d PostgresqlProviderData) importedObjectOID(ctx context.Context, identity *tfsdk.ResourceIdentity) (uint32, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var data ServerObjectIdentityModel
+
+	diags.Append(identity.Get(ctx, &data)...)
+
+	if diags.HasError() {
+		return 0, diags
+	}
+
+	var systemIdentifier string
+	if !data.SystemIdentifier.IsNull() {
+		var err error
+		if systemIdentifier, err = d.SystemIdentifier(ctx); err != nil {
+			diags.AddError("DB Query Error", fmt.Sprintf("Unable to check the system identifier of the imported resource, got error: %s", err))
+			return 0, diags
+		}
+	}
+
+	oid, err := data.importedOID(systemIdentifier)
+	if err != nil {
+		diags.AddError("Invalid import identity", err.Error())
+	}
+
+	return oid, diags
+}
+
+// importedOID returns the OID of the identity, checking that it's a valid OID and that the system identifier, if set,
+// is serverSystemIdentifier.
+func (m ServerObjectIdentityModel) importedOID(serverSystemIdentifier string) (uint32, error) {
+	if !m.SystemIdentifier.IsNull() && m.SystemIdentifier.ValueString() != serverSystemIdentifier {
+		return 0, fmt.Errorf("the resource belongs to the server with system identifier %s, but the provider is connected to the "+
+			"server with system identifier %s", m.SystemIdentifier.ValueString(), serverSystemIdentifier)
+	}
+
+	if m.Oid.IsNull() || m.Oid.ValueInt64() <= 0 || m.Oid.ValueInt64() > math.MaxUint32 {
+		return 0, fmt.Errorf("expected an oid between 1 and %d, got: %s", uint32(math.MaxUint32), m.Oid)
+	}
+
+	return uint32(m.Oid.ValueInt64()), nil
+}
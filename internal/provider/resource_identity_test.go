@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceIdentitySchemas(t *testing.T) {
+	t.Parallel()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	require.NoError(t, err)
+
+	resp, err := server.GetResourceIdentitySchemas(context.Background(), &tfprotov6.GetResourceIdentitySchemasRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.Diagnostics)
+
+	expectedAttributes := map[string][]string{
+		"postgresql_database":           {"oid", "system_identifier"},
+		"postgresql_default_privileges": {"database", "object_type", "owner", "role", "schema"},
+		"postgresql_grant":              {"database", "object_type", "objects", "role", "schema"},
+		"postgresql_grant_role":         {"member", "role"},
+		"postgresql_role":               {"oid", "system_identifier"},
+		"postgresql_schema":             {"database", "name"},
+	}
+
+	for resourceType, attributes := range expectedAttributes {
+		identitySchema, ok := resp.IdentitySchemas[resourceType]
+		require.True(t, ok, "missing identity schema of %s", resourceType)
+
+		var names []string
+		for _, attribute := range identitySchema.IdentityAttributes {
+			names = append(names, attribute.Name)
+		}
+		assert.ElementsMatch(t, attributes, names, resourceType)
+	}
+}
+
+func TestServerObjectIdentityImportedOID(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		identity         ServerObjectIdentityModel
+		systemIdentifier string
+		expectedOID      uint32
+		expectedErr      string
+	}{
+		{
+			testName:    "OID only",
+			identity:    ServerObjectIdentityModel{SystemIdentifier: types.StringNull(), Oid: types.Int64Value(16384)},
+			expectedOID: 16384,
+		},
+		{
+			testName:         "Matching system identifier",
+			identity:         ServerObjectIdentityModel{SystemIdentifier: types.StringValue("7301234567890123456"), Oid: types.Int64Value(16384)},
+			systemIdentifier: "7301234567890123456",
+			expectedOID:      16384,
+		},
+		{
+			testName:         "Other server",
+			identity:         ServerObjectIdentityModel{SystemIdentifier: types.StringValue("7301234567890123456"), Oid: types.Int64Value(16384)},
+			systemIdentifier: "7309876543210987654",
+			expectedErr:      "belongs to the server with system identifier 7301234567890123456",
+		},
+		{
+			testName:    "Largest OID",
+			identity:    ServerObjectIdentityModel{SystemIdentifier: types.StringNull(), Oid: types.Int64Value(4294967295)},
+			expectedOID: 4294967295,
+		},
+		{
+			testName:    "OID out of range",
+			identity:    ServerObjectIdentityModel{SystemIdentifier: types.StringNull(), Oid: types.Int64Value(4294967296)},
+			expectedErr: "expected an oid between 1 and 4294967295, got: 4294967296",
+		},
+		{
+			testName:    "Zero OID",
+			identity:    ServerObjectIdentityModel{SystemIdentifier: types.StringNull(), Oid: types.Int64Value(0)},
+			expectedErr: "expected an oid between 1 and 4294967295",
+		},
+		{
+			testName:    "Missing OID",
+			identity:    ServerObjectIdentityModel{SystemIdentifier: types.StringNull(), Oid: types.Int64Null()},
+			expectedErr: "expected an oid between 1 and 4294967295",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			oid, err := testCase.identity.importedOID(testCase.systemIdentifier)
+			if testCase.expectedErr != "" {
+				require.ErrorContains(t, err, testCase.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedOID, oid)
+		})
+	}
+}
+
+func TestSetServerObjectIdentity(t *testing.T) {
+	testCases := []struct {
+		testName                 string
+		priorIdentity            bool
+		priorSystemIdentifier    types.String
+		serverSystemIdentifier   string
+		expectedSystemIdentifier types.String
+		expectedWarning          bool
+	}{
+		{
+			testName:                 "New identity",
+			serverSystemIdentifier:   "7350853457212345678",
+			expectedSystemIdentifier: types.StringValue("7350853457212345678"),
+		},
+		{
+			// Nothing listens on the port, so the system identifier can't be queried.
+			testName:                 "New identity without system identifier",
+			expectedSystemIdentifier: types.StringNull(),
+			expectedWarning:          true,
+		},
+		{
+			testName:                 "Prior system identifier",
+			priorIdentity:            true,
+			priorSystemIdentifier:    types.StringValue("7350853457212345678"),
+			expectedSystemIdentifier: types.StringValue("7350853457212345678"),
+		},
+		{
+			testName:                 "Prior identity without system identifier",
+			priorIdentity:            true,
+			priorSystemIdentifier:    types.StringNull(),
+			serverSystemIdentifier:   "7350853457212345678",
+			expectedSystemIdentifier: types.StringValue("7350853457212345678"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			pool, err := pgxpool.New(ctx, "postgres://terraform@127.0.0.1:1/terraform_test?connect_timeout=5")
+			require.NoError(t, err)
+			defer pool.Close()
+
+			providerData := PostgresqlProviderData{
+				DbPool:           pool,
+				systemIdentifier: &serverSystemIdentifier{systemIdentifier: testCase.serverSystemIdentifier},
+			}
+
+			identitySchema := serverObjectIdentitySchema("role")
+			identity := &tfsdk.ResourceIdentity{
+				Schema: identitySchema,
+				Raw:    tftypes.NewValue(identitySchema.Type().TerraformType(ctx), nil),
+			}
+			if testCase.priorIdentity {
+				prior := ServerObjectIdentityModel{SystemIdentifier: testCase.priorSystemIdentifier, Oid: types.Int64Value(16384)}
+				require.Empty(t, identity.Set(ctx, prior))
+			}
+
+			diags := providerData.setServerObjectIdentity(ctx, identity, types.Int64Value(16385))
+			require.False(t, diags.HasError(), diags)
+			assert.Equal(t, testCase.expectedWarning, diags.WarningsCount() > 0)
+
+			var data ServerObjectIdentityModel
+			require.Empty(t, identity.Get(ctx, &data))
+			assert.Equal(t, testCase.expectedSystemIdentifier, data.SystemIdentifier)
+			assert.Equal(t, types.Int64Value(16385), data.Oid)
+		})
+	}
+}
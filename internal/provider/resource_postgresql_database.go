@@ -0,0 +1,597 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx/v5"
+	"github.com/ktham/terraform-provider-postgresql/internal/postgresql/pgsql"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DatabaseResource{}
+var _ resource.ResourceWithModifyPlan = &DatabaseResource{}
+var _ resource.ResourceWithImportState = &DatabaseResource{}
+var _ resource.ResourceWithIdentity = &DatabaseResource{}
+
+func NewDatabaseResource() resource.Resource {
+	return &DatabaseResource{}
+}
+
+type DatabaseResource struct {
+	data PostgresqlProviderData
+}
+
+type DatabaseResourceModel struct {
+	Oid              types.Int64  `tfsdk:"oid"`
+	Name             types.String `tfsdk:"name"`
+	Owner            types.String `tfsdk:"owner"`
+	Template         types.String `tfsdk:"template"`
+	Encoding         types.String `tfsdk:"encoding"`
+	LcCollate        types.String `tfsdk:"lc_collate"`
+	LcCtype          types.String `tfsdk:"lc_ctype"`
+	IcuLocale        types.String `tfsdk:"icu_locale"`
+	Tablespace       types.String `tfsdk:"tablespace"`
+	ConnectionLimit  types.Int32  `tfsdk:"connection_limit"`
+	AllowConnections types.Bool   `tfsdk:"allow_connections"`
+	IsTemplate       types.Bool   `tfsdk:"is_template"`
+	ForceDrop        types.Bool   `tfsdk:"force_drop"`
+}
+
+func (r *DatabaseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database"
+	// The system identifier of the identity is filled in once it can be determined.
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+func (r *DatabaseResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = serverObjectIdentitySchema("database")
+}
+
+func (r *DatabaseResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Postgresql Database",
+
+		Attributes: map[string]schema.Attribute{
+			"oid": schema.Int64Attribute{
+				Description: "The object ID of the Postgresql database.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the Postgresql database.",
+				Required:    true,
+			},
+			"owner": schema.StringAttribute{
+				Description: "The role that owns the database. Defaults to the role used by the provider to connect.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"template": schema.StringAttribute{
+				Description: "The name of the template from which to create the database. Postgres uses `template1` when not set. " +
+					"Postgres doesn't record the template a database was created from, so this value is not read back from the server.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"encoding": schema.StringAttribute{
+				Description: "The character set encoding to use in the database, e.g. `UTF8`.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"lc_collate": schema.StringAttribute{
+				Description: "The collation order (`LC_COLLATE`) to use in the database.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"lc_ctype": schema.StringAttribute{
+				Description: "The character classification (`LC_CTYPE`) to use in the database.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"icu_locale": schema.StringAttribute{
+				Description: "The ICU locale to use in the database. Setting this selects the `icu` locale provider and requires " +
+					"Postgres 15 or later. It usually needs `template` to be set to `template0`.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tablespace": schema.StringAttribute{
+				Description: "The name of the tablespace that will be associated with the database.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"connection_limit": schema.Int32Attribute{
+				Description: "Specifies how many concurrent connections can be made to the database. -1 (the default) means no limit.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int32default.StaticInt32(-1),
+				Validators: []validator.Int32{
+					int32validator.AtLeast(-1),
+				},
+			},
+			"allow_connections": schema.BoolAttribute{
+				Description: "Determines whether connections to the database are allowed.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"is_template": schema.BoolAttribute{
+				Description: "Determines whether the database can be cloned by any user with `CREATEDB` privileges.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"force_drop": schema.BoolAttribute{
+				Description: "Determines whether active connections to the database are terminated before dropping it, " +
+					"so that idle sessions don't block the deletion.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *DatabaseResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(PostgresqlProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected PostgresqlProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.data = data
+}
+
+func (r *DatabaseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.data.ConfigUnknown {
+		planUnknownValues(ctx, req, resp)
+	}
+}
+
+func (r *DatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var dataFromPlan DatabaseResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataFromPlan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if isKnown(dataFromPlan.IcuLocale) {
+		isPostgres15, version, diags := r.data.IsVersionAtLeast(ctx, 15)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !isPostgres15 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("icu_locale"),
+				"Unsupported Postgres version",
+				fmt.Sprintf("Setting `icu_locale` requires Postgres 15 or later, the server is running Postgres %s.", version),
+			)
+			return
+		}
+	}
+
+	// CREATE DATABASE cannot be executed inside a transaction block.
+	createDatabaseSql := fmt.Sprintf("CREATE DATABASE %s WITH %s;", pgsql.Identifier(dataFromPlan.Name.ValueString()), dataFromPlan.GetCreateOptionsString())
+
+	tflog.Info(ctx, createDatabaseSql)
+
+	if _, err := r.data.DbPool.Exec(ctx, createDatabaseSql); err != nil {
+		resp.Diagnostics.AddError("DB database creation error", fmt.Sprintf("Error executing query '%s', got error: %s", createDatabaseSql, err))
+		return
+	}
+
+	var databaseOID uint32
+	selectOidQuery := "SELECT oid FROM pg_database WHERE datname = $1"
+
+	err := r.data.DbPool.QueryRow(ctx, selectOidQuery, dataFromPlan.Name.ValueString()).Scan(&databaseOID)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to retrieve database OID", fmt.Sprintf("Error retrieving database OID with query `%s`, got error: %s", selectOidQuery, err))
+		return
+	}
+
+	dataFromPlan.Oid = types.Int64Value(int64(databaseOID))
+
+	if err := r.readDatabase(ctx, &dataFromPlan); err != nil {
+		resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("Unable to read back the created database, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Successfully created Postgresql Database: %s", dataFromPlan.Name.ValueString()))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromPlan)...)
+
+	resp.Diagnostics.Append(r.data.setServerObjectIdentity(ctx, resp.Identity, dataFromPlan.Oid)...)
+}
+
+func (r *DatabaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.data.ConfigUnknown {
+		return
+	}
+
+	var dataFromState DatabaseResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &dataFromState)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.readDatabase(ctx, &dataFromState)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			resp.Diagnostics.AddWarning("No results returned", fmt.Sprintf("The Postgres database couldn't be found. database: %s", dataFromState.Name.ValueString()))
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("SQL query to read database encountered an unexpected error, please share this with the developer, error: %s", err))
+		}
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromState)...)
+
+	resp.Diagnostics.Append(r.data.setServerObjectIdentity(ctx, resp.Identity, dataFromState.Oid)...)
+}
+
+func (r *DatabaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var dataFromPlan DatabaseResourceModel
+	var dataFromState DatabaseResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataFromPlan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &dataFromState)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	databaseName := pgsql.Identifier(dataFromPlan.Name.ValueString())
+
+	// ALTER DATABASE ... SET TABLESPACE cannot be executed inside a transaction block, so each statement is
+	// executed on its own.
+	var statements []string
+
+	if !dataFromPlan.Name.Equal(dataFromState.Name) {
+		statements = append(statements, fmt.Sprintf("ALTER DATABASE %s RENAME TO %s;", pgsql.Identifier(dataFromState.Name.ValueString()), databaseName))
+	}
+	if isKnown(dataFromPlan.Owner) && !dataFromPlan.Owner.Equal(dataFromState.Owner) {
+		statements = append(statements, fmt.Sprintf("ALTER DATABASE %s OWNER TO %s;", databaseName, pgsql.Identifier(dataFromPlan.Owner.ValueString())))
+	}
+	if isKnown(dataFromPlan.Tablespace) && !dataFromPlan.Tablespace.Equal(dataFromState.Tablespace) {
+		statements = append(statements, fmt.Sprintf("ALTER DATABASE %s SET TABLESPACE %s;", databaseName, pgsql.Identifier(dataFromPlan.Tablespace.ValueString())))
+	}
+	statements = append(statements, fmt.Sprintf("ALTER DATABASE %s WITH %s;", databaseName, dataFromPlan.GetAlterOptionsString()))
+
+	// A database can't be renamed while the provider is connected to it.
+	if !dataFromPlan.Name.Equal(dataFromState.Name) {
+		r.data.Pools.ClosePool(dataFromState.Name.ValueString())
+	}
+
+	for _, alterDatabaseSql := range statements {
+		tflog.Info(ctx, alterDatabaseSql)
+
+		if _, err := r.data.DbPool.Exec(ctx, alterDatabaseSql); err != nil {
+			resp.Diagnostics.AddError("DB database update error", fmt.Sprintf("Error executing query '%s', got error: %s", alterDatabaseSql, err))
+			return
+		}
+	}
+
+	if err := r.readDatabase(ctx, &dataFromPlan); err != nil {
+		resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("Unable to read back the updated database, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Successfully altered Postgresql Database: %s", dataFromPlan.Name.ValueString()))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromPlan)...)
+
+	resp.Diagnostics.Append(r.data.setServerObjectIdentity(ctx, resp.Identity, dataFromPlan.Oid)...)
+}
+
+func (r *DatabaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DatabaseResourceModel
+
+	// Read Terraform prior state data into the model...
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	databaseName := pgsql.Identifier(data.Name.ValueString())
+
+	var statements []pgsql.Statement
+
+	// Template databases cannot be dropped.
+	if data.IsTemplate.ValueBool() {
+		statements = append(statements, pgsql.NewStatement(fmt.Sprintf("ALTER DATABASE %s WITH IS_TEMPLATE false;", databaseName)))
+	}
+
+	dropDatabaseSql := fmt.Sprintf("DROP DATABASE %s;", databaseName)
+
+	if data.ForceDrop.ValueBool() {
+		isPostgres13, _, diags := r.data.IsVersionAtLeast(ctx, 13)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if isPostgres13 {
+			dropDatabaseSql = fmt.Sprintf("DROP DATABASE %s WITH (FORCE);", databaseName)
+		} else {
+			// Prevent new sessions from connecting while the existing ones are terminated.
+			statements = append(statements, pgsql.NewStatement(fmt.Sprintf("ALTER DATABASE %s WITH ALLOW_CONNECTIONS false;", databaseName)))
+			statements = append(statements, pgsql.NewStatement(
+				"SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1 AND pid <> pg_backend_pid();",
+				data.Name.ValueString(),
+			))
+		}
+	}
+
+	statements = append(statements, pgsql.NewStatement(dropDatabaseSql))
+
+	// A database can't be dropped while the provider is connected to it.
+	r.data.Pools.ClosePool(data.Name.ValueString())
+
+	for _, statement := range statements {
+		tflog.Info(ctx, statement.SQL)
+
+		if _, err := r.data.DbPool.Exec(ctx, statement.SQL, statement.Args...); err != nil {
+			resp.Diagnostics.AddError("DB database deletion error", fmt.Sprintf("Error executing query '%s', got error: %s", statement.SQL, err))
+			return
+		}
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Successfully dropped Postgresql Database: %s", data.Name.ValueString()))
+}
+
+func (r *DatabaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.data.ConfigUnknown {
+		resp.Diagnostics.AddError(
+			"Unknown provider configuration",
+			"Databases can't be imported while the provider configuration has values which are only known during apply.",
+		)
+		return
+	}
+
+	query := "SELECT oid, datname FROM pg_database WHERE datname = $1"
+	var arg any = req.ID
+	notFoundMessage := fmt.Sprintf("No Postgres database named '%s' exists.", req.ID)
+
+	// Import blocks may identify the database with its identity instead of an import ID.
+	if req.ID == "" {
+		identityOID, diags := r.data.importedObjectOID(ctx, req.Identity)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		query = "SELECT oid, datname FROM pg_database WHERE oid = $1"
+		arg = identityOID
+		notFoundMessage = fmt.Sprintf("No Postgres database with OID %d exists.", identityOID)
+	}
+
+	var databaseOID uint32
+	var databaseName string
+
+	err := r.data.DbPool.QueryRow(ctx, query, arg).Scan(&databaseOID, &databaseName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			resp.Diagnostics.AddError("Database not found", notFoundMessage)
+		} else {
+			resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("Unable to look up the database to import, got error: %s", err))
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(seedImportedDatabase(ctx, resp.State.SetAttribute, databaseOID, databaseName)...)
+}
+
+// seedImportedDatabase sets the attributes of an imported database which Read doesn't refresh with setAttribute.
+func seedImportedDatabase(ctx context.Context, setAttribute func(context.Context, path.Path, any) diag.Diagnostics, databaseOID uint32, databaseName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(setAttribute(ctx, path.Root("oid"), int64(databaseOID))...)
+	diags.Append(setAttribute(ctx, path.Root("name"), databaseName)...)
+	diags.Append(setAttribute(ctx, path.Root("force_drop"), false)...)
+
+	return diags
+}
+
+// readDatabase refreshes every attribute of the model that is stored in pg_database from the database identified by
+// the model's OID. It returns pgx.ErrNoRows if the database no longer exists.
+func (r *DatabaseResource) readDatabase(ctx context.Context, data *DatabaseResourceModel) error {
+	isPostgres15, _, err := r.data.isVersionAtLeast(ctx, 15)
+	if err != nil {
+		return err
+	}
+
+	isPostgres17, _, err := r.data.isVersionAtLeast(ctx, 17)
+	if err != nil {
+		return err
+	}
+
+	icuLocaleColumn := "NULL::text"
+	if isPostgres17 {
+		icuLocaleColumn = "CASE WHEN d.datlocprovider = 'i' THEN d.datlocale END"
+	} else if isPostgres15 {
+		icuLocaleColumn = "d.daticulocale"
+	}
+
+	databaseSql := fmt.Sprintf(`
+SELECT
+    d.datname,
+    pg_get_userbyid(d.datdba),
+    pg_encoding_to_char(d.encoding),
+    d.datcollate,
+    d.datctype,
+    %s,
+    t.spcname,
+    d.datconnlimit,
+    d.datallowconn,
+    d.datistemplate
+FROM
+    pg_database d
+    JOIN pg_tablespace t ON t.oid = d.dattablespace
+WHERE
+    d.oid = $1;`, icuLocaleColumn)
+
+	var name string
+	var owner string
+	var encoding string
+	var lcCollate string
+	var lcCtype string
+	var icuLocale *string
+	var tablespace string
+	var connectionLimit int32
+	var allowConnections bool
+	var isTemplate bool
+
+	err = r.data.DbPool.QueryRow(ctx, databaseSql, data.Oid.ValueInt64()).Scan(
+		&name,
+		&owner,
+		&encoding,
+		&lcCollate,
+		&lcCtype,
+		&icuLocale,
+		&tablespace,
+		&connectionLimit,
+		&allowConnections,
+		&isTemplate,
+	)
+	if err != nil {
+		return err
+	}
+
+	data.Name = types.StringValue(name)
+	data.Owner = types.StringValue(owner)
+	// Postgres reports the canonical name of the encoding, keep the user's spelling of it to avoid a replacement.
+	if !isKnown(data.Encoding) || !encodingNamesEqual(data.Encoding.ValueString(), encoding) {
+		data.Encoding = types.StringValue(encoding)
+	}
+	data.LcCollate = types.StringValue(lcCollate)
+	data.LcCtype = types.StringValue(lcCtype)
+	data.IcuLocale = types.StringPointerValue(icuLocale)
+	data.Tablespace = types.StringValue(tablespace)
+	data.ConnectionLimit = types.Int32Value(connectionLimit)
+	data.AllowConnections = types.BoolValue(allowConnections)
+	data.IsTemplate = types.BoolValue(isTemplate)
+
+	return nil
+}
+
+func (r *DatabaseResourceModel) GetCreateOptionsString() string {
+	var options []string
+
+	if isKnown(r.Owner) {
+		options = append(options, fmt.Sprintf("OWNER = %s", pgsql.Identifier(r.Owner.ValueString())))
+	}
+	if isKnown(r.Template) {
+		options = append(options, fmt.Sprintf("TEMPLATE = %s", pgsql.Identifier(r.Template.ValueString())))
+	}
+	if isKnown(r.Encoding) {
+		options = append(options, fmt.Sprintf("ENCODING = %s", pgsql.Literal(r.Encoding.ValueString())))
+	}
+	if isKnown(r.LcCollate) {
+		options = append(options, fmt.Sprintf("LC_COLLATE = %s", pgsql.Literal(r.LcCollate.ValueString())))
+	}
+	if isKnown(r.LcCtype) {
+		options = append(options, fmt.Sprintf("LC_CTYPE = %s", pgsql.Literal(r.LcCtype.ValueString())))
+	}
+	if isKnown(r.IcuLocale) {
+		options = append(options, "LOCALE_PROVIDER = icu")
+		options = append(options, fmt.Sprintf("ICU_LOCALE = %s", pgsql.Literal(r.IcuLocale.ValueString())))
+	}
+	if isKnown(r.Tablespace) {
+		options = append(options, fmt.Sprintf("TABLESPACE = %s", pgsql.Identifier(r.Tablespace.ValueString())))
+	}
+
+	options = append(options, r.GetAlterOptionsString())
+
+	return strings.Join(options, " ")
+}
+
+func (r *DatabaseResourceModel) GetAlterOptionsString() string {
+	options := []string{
+		fmt.Sprintf("ALLOW_CONNECTIONS = %t", r.AllowConnections.ValueBool()),
+		fmt.Sprintf("CONNECTION LIMIT = %d", r.ConnectionLimit.ValueInt32()),
+		fmt.Sprintf("IS_TEMPLATE = %t", r.IsTemplate.ValueBool()),
+	}
+
+	return strings.Join(options, " ")
+}
+
+// isKnown reports whether a Terraform value has been set to a concrete value.
+func isKnown(value attr.Value) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
+
+// encodingNamesEqual reports whether two encoding names refer to the same encoding, since Postgres accepts e.g.
+// `utf-8` for `UTF8`.
+func encodingNamesEqual(a string, b string) bool {
+	normalize := strings.NewReplacer("-", "", "_", "")
+
+	return strings.EqualFold(normalize.Replace(a), normalize.Replace(b))
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackc/pgx/v5"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &DatabaseListResource{}
+var _ list.ListResourceWithConfigure = &DatabaseListResource{}
+
+func NewDatabaseListResource() list.ListResource {
+	return &DatabaseListResource{}
+}
+
+// DatabaseListResource lists the databases of the server.
+type DatabaseListResource struct {
+	database DatabaseResource
+}
+
+type DatabaseListResourceModel struct {
+	NamePattern types.String `tfsdk:"name_pattern"`
+}
+
+func (r *DatabaseListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	r.database.Metadata(ctx, req, resp)
+}
+
+func (r *DatabaseListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the Postgresql databases of the server.",
+
+		Attributes: map[string]schema.Attribute{
+			"name_pattern": schema.StringAttribute{
+				Description: "A `LIKE` pattern the names of the listed databases match, such as `app\\_%`.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *DatabaseListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.database.Configure(ctx, req, resp)
+}
+
+func (r *DatabaseListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var diags diag.Diagnostics
+
+	if r.database.data.ConfigUnknown {
+		diags.AddError(
+			"Unknown provider configuration",
+			"Databases can't be listed while the provider configuration has values which are only known during apply.",
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var filters DatabaseListResourceModel
+
+	diags.Append(req.Config.Get(ctx, &filters)...)
+
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	// The identities lack the system identifier, along with a warning, when it can't be determined.
+	identity, identityDiags := r.database.data.serverObjectIdentity(ctx, types.Int64Null())
+
+	var query listQuery
+	if !filters.NamePattern.IsNull() {
+		query.whereArg("datname LIKE", filters.NamePattern.ValueString())
+	}
+
+	querySql, args := query.build("SELECT oid, datname FROM pg_database", "datname", req.Limit)
+
+	databases, err := queryListedObjects(ctx, r.database.data.DbPool, querySql, args)
+	if err != nil {
+		diags.AddError("DB Query Error", fmt.Sprintf("Unable to list the databases, got error: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		if len(identityDiags) > 0 && !push(list.ListResult{Diagnostics: identityDiags}) {
+			return
+		}
+
+		for _, database := range databases {
+			result := req.NewListResult(ctx)
+			result.DisplayName = database.name
+
+			identity.Oid = types.Int64Value(int64(database.oid))
+			result.Diagnostics.Append(result.Identity.Set(ctx, identity)...)
+
+			if req.IncludeResource {
+				result.Diagnostics.Append(r.database.readListedDatabase(ctx, result.Resource, database)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// readListedDatabase sets resource to the state of a listed database, as if it was imported.
+func (r *DatabaseResource) readListedDatabase(ctx context.Context, resource *tfsdk.Resource, database listedObject) diag.Diagnostics {
+	diags := seedImportedDatabase(ctx, resource.SetAttribute, database.oid, database.name)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	var data DatabaseResourceModel
+	diags.Append(resource.Get(ctx, &data)...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := r.readDatabase(ctx, &data); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			diags.AddError("Database not found", fmt.Sprintf("Database '%s' was dropped while it was listed.", database.name))
+		} else {
+			diags.AddError("DB Query Error", fmt.Sprintf("Unable to read database '%s', got error: %s", database.name, err))
+		}
+		return diags
+	}
+
+	diags.Append(resource.Set(ctx, &data)...)
+
+	return diags
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck/queryfilter"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccDatabaseListResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// List resources were introduced in Terraform 1.14
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig() + testAccDatabaseResourceConfig("list_database", 10, false),
+			},
+			{
+				Query: true,
+				Config: providerConfig() + `
+list "postgresql_database" "test" {
+  provider         = postgresql
+  include_resource = true
+
+  config {
+    name_pattern = "list\\_%"
+  }
+}
+`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("postgresql_database.test", 1),
+					querycheck.ExpectResourceDisplayName("postgresql_database.test", queryfilter.ByResourceIdentity(map[string]knownvalue.Check{
+						"system_identifier": knownvalue.NotNull(),
+						"oid":               knownvalue.NotNull(),
+					}), knownvalue.StringExact("list_database")),
+					querycheck.ExpectResourceKnownValues("postgresql_database.test", queryfilter.ByDisplayName(knownvalue.StringExact("list_database")), []querycheck.KnownValueCheck{
+						{Path: tfjsonpath.New("connection_limit"), KnownValue: knownvalue.Int64Exact(10)},
+						{Path: tfjsonpath.New("force_drop"), KnownValue: knownvalue.Bool(false)},
+					}),
+				},
+			},
+		},
+	})
+}
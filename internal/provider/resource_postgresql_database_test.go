@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccDatabaseResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test database creation
+			{
+				Config: providerConfig() + testAccDatabaseResourceConfig("database1", 10, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_database.test", "name", "database1"),
+					resource.TestCheckResourceAttr("postgresql_database.test", "owner", "terraform"),
+					resource.TestCheckResourceAttr("postgresql_database.test", "encoding", "UTF8"),
+					resource.TestCheckResourceAttr("postgresql_database.test", "tablespace", "pg_default"),
+					resource.TestCheckResourceAttr("postgresql_database.test", "connection_limit", "10"),
+					resource.TestCheckResourceAttr("postgresql_database.test", "allow_connections", "true"),
+					resource.TestCheckResourceAttr("postgresql_database.test", "is_template", "false"),
+					resource.TestCheckResourceAttrSet("postgresql_database.test", "oid"),
+				),
+			},
+			// Test database update
+			{
+				Config: providerConfig() + testAccDatabaseResourceConfig("database1", 20, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_database.test", "name", "database1"),
+					resource.TestCheckResourceAttr("postgresql_database.test", "connection_limit", "20"),
+					resource.TestCheckResourceAttr("postgresql_database.test", "is_template", "true"),
+				),
+			},
+			// Test database re-name
+			{
+				Config: providerConfig() + testAccDatabaseResourceConfig("database2", 20, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_database.test", "name", "database2"),
+					resource.TestCheckResourceAttr("postgresql_database.test", "connection_limit", "20"),
+				),
+			},
+			// Test database import
+			{
+				ResourceName:            "postgresql_database.test",
+				ImportState:             true,
+				ImportStateId:           "database2",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"template", "force_drop"},
+			},
+		},
+	})
+}
+
+func TestAccDatabaseResourceIdentity(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Resource identity was introduced in Terraform 1.12
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig() + `
+resource "postgresql_database" "test" {
+  name = "identity_database"
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValue("postgresql_database.test", tfjsonpath.New("system_identifier"), knownvalue.NotNull()),
+					statecheck.ExpectIdentityValueMatchesState("postgresql_database.test", tfjsonpath.New("oid")),
+				},
+			},
+			// Test database import with an import block identifying the database with its identity
+			{
+				ResourceName:    "postgresql_database.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func testAccDatabaseResourceConfig(name string, connectionLimit int32, isTemplate bool) string {
+	return fmt.Sprintf(`
+resource "postgresql_database" "test" {
+  name             = %[1]q
+  template         = "template0"
+  encoding         = "UTF8"
+  connection_limit = %d
+  is_template      = %t
+  force_drop       = true
+}
+`, name, connectionLimit, isTemplate)
+}
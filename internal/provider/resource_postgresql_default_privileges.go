@@ -0,0 +1,614 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx/v5"
+	"github.com/ktham/terraform-provider-postgresql/internal/postgresql/pgsql"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DefaultPrivilegesResource{}
+var _ resource.ResourceWithModifyPlan = &DefaultPrivilegesResource{}
+var _ resource.ResourceWithValidateConfig = &DefaultPrivilegesResource{}
+var _ resource.ResourceWithImportState = &DefaultPrivilegesResource{}
+var _ resource.ResourceWithIdentity = &DefaultPrivilegesResource{}
+
+func NewDefaultPrivilegesResource() resource.Resource {
+	return &DefaultPrivilegesResource{}
+}
+
+type DefaultPrivilegesResource struct {
+	data PostgresqlProviderData
+}
+
+type DefaultPrivilegesResourceModel struct {
+	Database        types.String `tfsdk:"database"`
+	Owner           types.String `tfsdk:"owner"`
+	Role            types.String `tfsdk:"role"`
+	Schema          types.String `tfsdk:"schema"`
+	ObjectType      types.String `tfsdk:"object_type"`
+	Privileges      types.Set    `tfsdk:"privileges"`
+	WithGrantOption types.Bool   `tfsdk:"with_grant_option"`
+}
+
+// DefaultPrivilegesResourceIdentityModel is the identity of default privileges, made of the attributes determining
+// which default privileges they manage.
+type DefaultPrivilegesResourceIdentityModel struct {
+	Database   types.String `tfsdk:"database"`
+	Owner      types.String `tfsdk:"owner"`
+	Role       types.String `tfsdk:"role"`
+	Schema     types.String `tfsdk:"schema"`
+	ObjectType types.String `tfsdk:"object_type"`
+}
+
+// defaultPrivilegesObjectType describes a kind of object whose default privileges can be altered.
+type defaultPrivilegesObjectType struct {
+	// keyword is the object kind used in `ALTER DEFAULT PRIVILEGES ... GRANT ... ON <keyword>`.
+	keyword string
+	// defaclObjType is the value of pg_default_acl.defaclobjtype for this kind of object.
+	defaclObjType string
+	// aclDefaultKind is the object type code passed to acldefault() to compute the built-in default privileges.
+	aclDefaultKind string
+	// privileges lists every privilege that can be granted on objects of this type.
+	privileges []string
+}
+
+var defaultPrivilegesObjectTypes = map[string]defaultPrivilegesObjectType{
+	"table": {
+		keyword:        "TABLES",
+		defaclObjType:  "r",
+		aclDefaultKind: "r",
+		privileges:     grantObjectTypes["table"].privileges,
+	},
+	"sequence": {
+		keyword:        "SEQUENCES",
+		defaclObjType:  "S",
+		aclDefaultKind: "s",
+		privileges:     grantObjectTypes["sequence"].privileges,
+	},
+	"function": {
+		keyword:        "FUNCTIONS",
+		defaclObjType:  "f",
+		aclDefaultKind: "f",
+		privileges:     grantObjectTypes["function"].privileges,
+	},
+	"type": {
+		keyword:        "TYPES",
+		defaclObjType:  "T",
+		aclDefaultKind: "T",
+		privileges:     grantObjectTypes["type"].privileges,
+	},
+	"schema": {
+		keyword:        "SCHEMAS",
+		defaclObjType:  "n",
+		aclDefaultKind: "n",
+		privileges:     grantObjectTypes["schema"].privileges,
+	},
+}
+
+func (r *DefaultPrivilegesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_default_privileges"
+}
+
+func (r *DefaultPrivilegesResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"database": identityschema.StringAttribute{
+				Description:       "The database in which the default privileges apply. When importing, defaults to the database the provider is connected to.",
+				OptionalForImport: true,
+			},
+			"owner": identityschema.StringAttribute{
+				Description:       "The role creating the objects the default privileges apply to.",
+				RequiredForImport: true,
+			},
+			"role": identityschema.StringAttribute{
+				Description:       "The name of the role the privileges are granted to.",
+				RequiredForImport: true,
+			},
+			"schema": identityschema.StringAttribute{
+				Description:       "The schema in which the objects are created, unless the default privileges apply to every schema.",
+				OptionalForImport: true,
+			},
+			"object_type": identityschema.StringAttribute{
+				Description:       "The type of the objects the default privileges apply to.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *DefaultPrivilegesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	objectTypeNames := []string{"function", "schema", "sequence", "table", "type"}
+
+	resp.Schema = schema.Schema{
+		Description: "Postgresql Default Privileges. Manages the privileges a role is granted on objects created by another role " +
+			"in the future. The resource is authoritative for the role, owner, schema and object type it targets.",
+
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "The database in which the default privileges apply. Defaults to the database the provider is connected to.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Description: "The role creating the objects the default privileges apply to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Description: "The name of the role the privileges are granted to. Use `public` to grant the privileges to every role.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Description: "The schema in which the objects are created. When not set, the default privileges apply to " +
+					"objects created in any schema of the database, and replace the built-in default privileges. Must not be " +
+					"set when `object_type` is `schema`.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"object_type": schema.StringAttribute{
+				Description: "The type of the objects the default privileges apply to. One of `" + strings.Join(objectTypeNames, "`, `") + "`. " +
+					"The `schema` object type requires Postgres 10 or later.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(objectTypeNames...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"privileges": schema.SetAttribute{
+				Description: "The privileges to grant, e.g. `SELECT` or `USAGE`. An empty set revokes every privilege.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(grantablePrivileges()...)),
+				},
+			},
+			"with_grant_option": schema.BoolAttribute{
+				Description: "Determines whether the role may grant the privileges on to other roles.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *DefaultPrivilegesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data DefaultPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || !isKnown(data.ObjectType) {
+		return
+	}
+
+	objectTypeName := data.ObjectType.ValueString()
+	objectType, ok := defaultPrivilegesObjectTypes[objectTypeName]
+	if !ok {
+		return
+	}
+
+	if objectTypeName == "schema" && !data.Schema.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schema"),
+			"Unexpected schema",
+			"`schema` must not be set when `object_type` is `schema`.",
+		)
+	}
+
+	if data.Privileges.IsUnknown() {
+		return
+	}
+
+	var privileges []types.String
+	resp.Diagnostics.Append(data.Privileges.ElementsAs(ctx, &privileges, false)...)
+
+	for _, privilege := range privileges {
+		if isKnown(privilege) && !slices.Contains(objectType.privileges, privilege.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("privileges"),
+				"Invalid privilege",
+				fmt.Sprintf("The %s privilege cannot be granted on objects of type `%s`, valid privileges are: %s.",
+					privilege.ValueString(), objectTypeName, strings.Join(objectType.privileges, ", ")),
+			)
+		}
+	}
+}
+
+func (r *DefaultPrivilegesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(PostgresqlProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected PostgresqlProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.data = data
+}
+
+func (r *DefaultPrivilegesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.data.ConfigUnknown {
+		planUnknownValues(ctx, req, resp)
+	}
+}
+
+func (r *DefaultPrivilegesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var dataFromPlan DefaultPrivilegesResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataFromPlan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if dataFromPlan.ObjectType.ValueString() == "schema" {
+		isPostgres10, version, diags := r.data.IsVersionAtLeast(ctx, 10)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !isPostgres10 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("object_type"),
+				"Unsupported Postgres version",
+				fmt.Sprintf("Default privileges on schemas require Postgres 10 or later, the server is running Postgres %s.", version),
+			)
+			return
+		}
+	}
+
+	if !isKnown(dataFromPlan.Database) {
+		dataFromPlan.Database = types.StringValue(r.data.DatabaseName())
+	}
+
+	var privileges []string
+	resp.Diagnostics.Append(dataFromPlan.Privileges.ElementsAs(ctx, &privileges, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.applyDefaultPrivileges(ctx, &dataFromPlan, privileges, dataFromPlan.WithGrantOption.ValueBool()); err != nil {
+		resp.Diagnostics.AddError("DB default privileges error", fmt.Sprintf("Unable to alter default privileges of role '%s', got error: %s", dataFromPlan.Role.ValueString(), err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Successfully altered default privileges of Postgresql Role: %s", dataFromPlan.Role.ValueString()))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromPlan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, dataFromPlan.identity())...)
+}
+
+func (r *DefaultPrivilegesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.data.ConfigUnknown {
+		return
+	}
+
+	var dataFromState DefaultPrivilegesResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &dataFromState)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	objectType := defaultPrivilegesObjectTypes[dataFromState.ObjectType.ValueString()]
+
+	pool, err := r.data.DatabasePool(ctx, dataFromState.Database.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("DB Connection Error", fmt.Sprintf("Unable to connect to database '%s', got error: %s", dataFromState.Database.ValueString(), err))
+		return
+	}
+
+	var ownerOID uint32
+	var roleOID uint32
+	var schemaOID uint32
+
+	err = pool.QueryRow(ctx, "SELECT oid FROM pg_roles WHERE rolname = $1", dataFromState.Owner.ValueString()).Scan(&ownerOID)
+	if err == nil && !strings.EqualFold(dataFromState.Role.ValueString(), "public") {
+		err = pool.QueryRow(ctx, "SELECT oid FROM pg_roles WHERE rolname = $1", dataFromState.Role.ValueString()).Scan(&roleOID)
+	}
+	if err == nil && !dataFromState.Schema.IsNull() {
+		err = pool.QueryRow(ctx, "SELECT oid FROM pg_namespace WHERE nspname = $1", dataFromState.Schema.ValueString()).Scan(&schemaOID)
+	}
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			resp.Diagnostics.AddWarning("No results returned", fmt.Sprintf("The owner, role or schema of the default privileges couldn't be found. role: %s", dataFromState.Role.ValueString()))
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("Unable to look up the default privileges, got error: %s", err))
+		}
+		return
+	}
+
+	// Without a pg_default_acl entry, the built-in defaults apply to the global default privileges, whereas nothing
+	// beyond the global default privileges applies to a schema.
+	aclDefault := "NULL"
+	if dataFromState.Schema.IsNull() {
+		aclDefault = fmt.Sprintf("acldefault('%s', $1)", objectType.aclDefaultKind)
+	}
+
+	defaultPrivilegesSql := fmt.Sprintf(`
+SELECT
+    a.privilege_type,
+    a.is_grantable
+FROM
+    aclexplode(coalesce(
+        (SELECT defaclacl FROM pg_default_acl WHERE defaclrole = $1 AND defaclnamespace = $2 AND defaclobjtype = '%s'),
+        %s
+    )) a
+WHERE
+    a.grantee = $3;`, objectType.defaclObjType, aclDefault)
+
+	rows, err := pool.Query(ctx, defaultPrivilegesSql, ownerOID, schemaOID, roleOID)
+	if err != nil {
+		resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("SQL query to read default privileges encountered an unexpected error, please share this with the developer, query=`%s`, error: %s", defaultPrivilegesSql, err))
+		return
+	}
+
+	// Not nil, so that no default privileges are stored as an empty set rather than null, matching `privileges = []`.
+	privileges := []string{}
+	withGrantOption := true
+
+	var privilege string
+	var isGrantable bool
+	_, err = pgx.ForEachRow(rows, []any{&privilege, &isGrantable}, func() error {
+		if slices.Contains(objectType.privileges, privilege) {
+			privileges = append(privileges, privilege)
+			withGrantOption = withGrantOption && isGrantable
+		}
+		return nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("SQL query to read default privileges encountered an unexpected error, please share this with the developer, query=`%s`, error: %s", defaultPrivilegesSql, err))
+		return
+	}
+
+	privilegesValue, diags := types.SetValueFrom(ctx, types.StringType, privileges)
+	resp.Diagnostics.Append(diags...)
+
+	dataFromState.Privileges = privilegesValue
+	dataFromState.WithGrantOption = types.BoolValue(withGrantOption && len(privileges) > 0)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, dataFromState.identity())...)
+}
+
+func (r *DefaultPrivilegesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var dataFromPlan DefaultPrivilegesResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataFromPlan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var privileges []string
+	resp.Diagnostics.Append(dataFromPlan.Privileges.ElementsAs(ctx, &privileges, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.applyDefaultPrivileges(ctx, &dataFromPlan, privileges, dataFromPlan.WithGrantOption.ValueBool()); err != nil {
+		resp.Diagnostics.AddError("DB default privileges error", fmt.Sprintf("Unable to alter default privileges of role '%s', got error: %s", dataFromPlan.Role.ValueString(), err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Successfully altered default privileges of Postgresql Role: %s", dataFromPlan.Role.ValueString()))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromPlan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, dataFromPlan.identity())...)
+}
+
+func (r *DefaultPrivilegesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DefaultPrivilegesResourceModel
+
+	// Read Terraform prior state data into the model...
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Deleting per-schema default privileges leaves nothing behind, while deleting global default privileges restores
+	// the built-in defaults, e.g. EXECUTE on functions for PUBLIC.
+	var privileges []string
+	if data.Schema.IsNull() {
+		var err error
+		privileges, err = r.builtInDefaultPrivileges(ctx, &data)
+		if err != nil {
+			resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("Unable to look up the built-in default privileges, got error: %s", err))
+			return
+		}
+	}
+
+	if err := r.applyDefaultPrivileges(ctx, &data, privileges, false); err != nil {
+		resp.Diagnostics.AddError("DB default privileges error", fmt.Sprintf("Unable to revoke default privileges of role '%s', got error: %s", data.Role.ValueString(), err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Successfully revoked default privileges of Postgresql Role: %s", data.Role.ValueString()))
+}
+
+// ImportState imports default privileges using the identity of an import block. Role and schema names may contain any
+// character, so there is no import ID.
+func (r *DefaultPrivilegesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != "" {
+		resp.Diagnostics.AddError(
+			"Unsupported import ID",
+			"Default privileges can only be imported with the `identity` of an `import` block, which requires Terraform 1.12 or later.",
+		)
+		return
+	}
+
+	var identity DefaultPrivilegesResourceIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if identity.Owner.ValueString() == "" || identity.Role.ValueString() == "" {
+		resp.Diagnostics.AddError("Invalid import identity", "Expected the owner and the role of the default privileges to be set.")
+		return
+	}
+
+	if _, ok := defaultPrivilegesObjectTypes[identity.ObjectType.ValueString()]; !ok {
+		resp.Diagnostics.AddError(
+			"Invalid import identity",
+			fmt.Sprintf("Expected object_type to be one of `%s`, got: %s", strings.Join(slices.Sorted(maps.Keys(defaultPrivilegesObjectTypes)), "`, `"), identity.ObjectType),
+		)
+		return
+	}
+
+	database := identity.Database
+	if database.IsNull() {
+		database = types.StringValue(r.data.DatabaseName())
+	}
+
+	// Read refreshes the privileges.
+	data := DefaultPrivilegesResourceModel{
+		Database:        database,
+		Owner:           identity.Owner,
+		Role:            identity.Role,
+		Schema:          identity.Schema,
+		ObjectType:      identity.ObjectType,
+		Privileges:      types.SetValueMust(types.StringType, nil),
+		WithGrantOption: types.BoolValue(false),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// identity returns the identity of the default privileges.
+func (m DefaultPrivilegesResourceModel) identity() DefaultPrivilegesResourceIdentityModel {
+	return DefaultPrivilegesResourceIdentityModel{
+		Database:   m.Database,
+		Owner:      m.Owner,
+		Role:       m.Role,
+		Schema:     m.Schema,
+		ObjectType: m.ObjectType,
+	}
+}
+
+// applyDefaultPrivileges revokes every default privilege of the role, then grants the given privileges, within a
+// single transaction.
+func (r *DefaultPrivilegesResource) applyDefaultPrivileges(ctx context.Context, data *DefaultPrivilegesResourceModel, privileges []string, withGrantOption bool) error {
+	objectType := defaultPrivilegesObjectTypes[data.ObjectType.ValueString()]
+	slices.Sort(privileges)
+
+	pool, err := r.data.DatabasePool(ctx, data.Database.ValueString())
+	if err != nil {
+		return fmt.Errorf("unable to connect to database '%s': %w", data.Database.ValueString(), err)
+	}
+
+	statements := []string{buildAlterDefaultPrivilegesStatement(data.Owner.ValueString(), data.Schema.ValueString(), buildRevokeAllStatement("ON "+objectType.keyword, data.Role.ValueString()))}
+	if len(privileges) > 0 {
+		grantSql := buildGrantStatement("ON "+objectType.keyword, data.Role.ValueString(), privileges, withGrantOption)
+		statements = append(statements, buildAlterDefaultPrivilegesStatement(data.Owner.ValueString(), data.Schema.ValueString(), grantSql))
+	}
+
+	return pgx.BeginFunc(ctx, pool, func(txn pgx.Tx) error {
+		for _, statement := range statements {
+			tflog.Info(ctx, statement)
+
+			if _, err := txn.Exec(ctx, statement); err != nil {
+				return fmt.Errorf("error executing query '%s': %w", statement, err)
+			}
+		}
+		return nil
+	})
+}
+
+// builtInDefaultPrivileges returns the privileges Postgres grants the role by default on objects of the resource's type
+// created by the owner, when no default privileges have been altered.
+func (r *DefaultPrivilegesResource) builtInDefaultPrivileges(ctx context.Context, data *DefaultPrivilegesResourceModel) ([]string, error) {
+	objectType := defaultPrivilegesObjectTypes[data.ObjectType.ValueString()]
+
+	builtInDefaultsSql := fmt.Sprintf(`
+SELECT
+    a.privilege_type
+FROM
+    pg_roles o
+    CROSS JOIN LATERAL aclexplode(acldefault('%s', o.oid)) a
+WHERE
+    o.rolname = $1
+    AND a.grantee = coalesce((SELECT oid FROM pg_roles WHERE rolname = $2 AND lower($2) <> 'public'), 0);`, objectType.aclDefaultKind)
+
+	rows, err := r.data.DbPool.Query(ctx, builtInDefaultsSql, data.Owner.ValueString(), data.Role.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	var privileges []string
+	var privilege string
+	_, err = pgx.ForEachRow(rows, []any{&privilege}, func() error {
+		if slices.Contains(objectType.privileges, privilege) {
+			privileges = append(privileges, privilege)
+		}
+		return nil
+	})
+
+	return privileges, err
+}
+
+// buildAlterDefaultPrivilegesStatement wraps a GRANT or REVOKE statement into an ALTER DEFAULT PRIVILEGES statement for
+// objects created by owner, optionally restricted to schema.
+func buildAlterDefaultPrivilegesStatement(owner string, schema string, grantOrRevokeSql string) string {
+	alterSql := fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR ROLE %s", pgsql.Identifier(owner))
+
+	if schema != "" {
+		alterSql += fmt.Sprintf(" IN SCHEMA %s", pgsql.Identifier(schema))
+	}
+
+	return alterSql + " " + grantOrRevokeSql
+}
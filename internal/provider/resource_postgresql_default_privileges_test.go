@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccDefaultPrivilegesResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test creating global and per-schema default privileges
+			{
+				Config: providerConfig() + testAccDefaultPrivilegesResourceConfig(`["SELECT"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_default_privileges.schema_tables", "database", "terraform_test"),
+					resource.TestCheckResourceAttr("postgresql_default_privileges.schema_tables", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("postgresql_default_privileges.schema_tables", "privileges.*", "SELECT"),
+					resource.TestCheckResourceAttr("postgresql_default_privileges.global_functions", "privileges.#", "0"),
+				),
+			},
+			// Test updating privileges
+			{
+				Config: providerConfig() + testAccDefaultPrivilegesResourceConfig(`["SELECT", "UPDATE"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_default_privileges.schema_tables", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("postgresql_default_privileges.schema_tables", "privileges.*", "UPDATE"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDefaultPrivilegesResourceIdentity(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Resource identity was introduced in Terraform 1.12
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig() + testAccDefaultPrivilegesResourceConfig(`["SELECT"]`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("postgresql_default_privileges.schema_tables", map[string]knownvalue.Check{
+						"database":    knownvalue.StringExact("terraform_test"),
+						"owner":       knownvalue.StringExact("default_privileges_owner"),
+						"role":        knownvalue.StringExact("default_privileges_grantee"),
+						"schema":      knownvalue.StringExact("default_privileges_schema"),
+						"object_type": knownvalue.StringExact("table"),
+					}),
+				},
+			},
+			// Test default privileges import with an import block identifying them with their identity
+			{
+				ResourceName:    "postgresql_default_privileges.schema_tables",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+			{
+				ResourceName:    "postgresql_default_privileges.global_functions",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func testAccDefaultPrivilegesResourceConfig(tablePrivileges string) string {
+	return fmt.Sprintf(`
+resource "postgresql_role" "owner" {
+  name = "default_privileges_owner"
+}
+
+resource "postgresql_role" "grantee" {
+  name = "default_privileges_grantee"
+}
+
+resource "postgresql_schema" "test" {
+  name  = "default_privileges_schema"
+  owner = postgresql_role.owner.name
+}
+
+resource "postgresql_default_privileges" "schema_tables" {
+  owner       = postgresql_role.owner.name
+  role        = postgresql_role.grantee.name
+  schema      = postgresql_schema.test.name
+  object_type = "table"
+  privileges  = %[1]s
+}
+
+# Revoke the built-in EXECUTE privilege of PUBLIC on new functions
+resource "postgresql_default_privileges" "global_functions" {
+  owner       = postgresql_role.owner.name
+  role        = "public"
+  object_type = "function"
+  privileges  = []
+}
+`, tablePrivileges)
+}
@@ -0,0 +1,800 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"slices"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GrantResource{}
+var _ resource.ResourceWithModifyPlan = &GrantResource{}
+var _ resource.ResourceWithValidateConfig = &GrantResource{}
+var _ resource.ResourceWithImportState = &GrantResource{}
+var _ resource.ResourceWithIdentity = &GrantResource{}
+
+func NewGrantResource() resource.Resource {
+	return &GrantResource{}
+}
+
+type GrantResource struct {
+	data PostgresqlProviderData
+}
+
+type GrantResourceModel struct {
+	Database        types.String `tfsdk:"database"`
+	Role            types.String `tfsdk:"role"`
+	ObjectType      types.String `tfsdk:"object_type"`
+	Schema          types.String `tfsdk:"schema"`
+	Objects         types.Set    `tfsdk:"objects"`
+	Privileges      types.Set    `tfsdk:"privileges"`
+	WithGrantOption types.Bool   `tfsdk:"with_grant_option"`
+	RevokeCascade   types.Bool   `tfsdk:"revoke_cascade"`
+}
+
+// GrantResourceIdentityModel is the identity of a grant, made of the attributes determining which privileges it
+// manages. The objects are sorted, as identities can't hold sets.
+type GrantResourceIdentityModel struct {
+	Database   types.String `tfsdk:"database"`
+	Role       types.String `tfsdk:"role"`
+	ObjectType types.String `tfsdk:"object_type"`
+	Schema     types.String `tfsdk:"schema"`
+	Objects    types.List   `tfsdk:"objects"`
+}
+
+func (r *GrantResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_grant"
+}
+
+func (r *GrantResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"database": identityschema.StringAttribute{
+				Description:       "The database containing the objects. When importing, defaults to the database the provider is connected to.",
+				OptionalForImport: true,
+			},
+			"role": identityschema.StringAttribute{
+				Description:       "The name of the role the privileges are granted to.",
+				RequiredForImport: true,
+			},
+			"object_type": identityschema.StringAttribute{
+				Description:       "The type of the objects the privileges are granted on.",
+				RequiredForImport: true,
+			},
+			"schema": identityschema.StringAttribute{
+				Description:       "The schema containing the objects, for the object types which are in a schema.",
+				OptionalForImport: true,
+			},
+			"objects": identityschema.ListAttribute{
+				Description:       "The sorted names of the objects the privileges are granted on, unless they're granted on every object of the schema.",
+				ElementType:       types.StringType,
+				OptionalForImport: true,
+			},
+		},
+	}
+}
+
+func (r *GrantResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Postgresql Grant. Manages the privileges a role holds on a set of objects. The resource is authoritative: " +
+			"privileges held by the role on those objects that aren't listed in `privileges` are revoked.",
+
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "The database containing the objects. Defaults to the database the provider is connected to.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Description: "The name of the role the privileges are granted to. Use `public` to grant the privileges to every role.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"object_type": schema.StringAttribute{
+				Description: "The type of the objects the privileges are granted on. One of `" + strings.Join(grantObjectTypeNames(), "`, `") + "`.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(grantObjectTypeNames()...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Description: "The schema containing the objects. Required for the `table`, `sequence`, `function`, `procedure`, " +
+					"`routine`, `type` and `domain` object types, and must not be set for the others.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"objects": schema.SetAttribute{
+				Description: "The names of the objects the privileges are granted on. Functions, procedures and routines may " +
+					"be given either by name, which targets every overload, or by signature, e.g. `my_function(integer, text)`. " +
+					"Large objects are given by OID. When not set for the `table`, `sequence`, `function`, `procedure` and " +
+					"`routine` object types, the privileges are granted on every object of that type in the schema. Must not " +
+					"be set for the `database` object type, which always targets `database`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"privileges": schema.SetAttribute{
+				Description: "The privileges to grant, e.g. `SELECT` or `USAGE`. An empty set revokes every privilege.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(grantablePrivileges()...)),
+				},
+			},
+			"with_grant_option": schema.BoolAttribute{
+				Description: "Determines whether the role may grant the privileges on to other roles.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"revoke_cascade": schema.BoolAttribute{
+				Description: "Determines whether revoking privileges, or their grant option, also revokes the privileges the " +
+					"role granted on to other roles with the grant option, with `CASCADE`. Without it, such revokes fail. " +
+					"Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *GrantResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data GrantResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || !isKnown(data.ObjectType) {
+		return
+	}
+
+	objectTypeName := data.ObjectType.ValueString()
+	objectType, ok := grantObjectTypes[objectTypeName]
+	if !ok {
+		return
+	}
+
+	if objectType.inSchema && data.Schema.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schema"),
+			"Missing schema",
+			fmt.Sprintf("`schema` must be set when `object_type` is `%s`.", objectTypeName),
+		)
+	}
+	if !objectType.inSchema && !data.Schema.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schema"),
+			"Unexpected schema",
+			fmt.Sprintf("`schema` must not be set when `object_type` is `%s`.", objectTypeName),
+		)
+	}
+	if objectTypeName == "database" && !data.Objects.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("objects"),
+			"Unexpected objects",
+			"`objects` must not be set when `object_type` is `database`, the privileges are granted on `database`.",
+		)
+	}
+	if objectTypeName != "database" && objectType.allInSchemaKeyword == "" && data.Objects.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("objects"),
+			"Missing objects",
+			fmt.Sprintf("`objects` must be set when `object_type` is `%s`.", objectTypeName),
+		)
+	}
+
+	if data.Privileges.IsUnknown() {
+		return
+	}
+
+	var privileges []types.String
+	resp.Diagnostics.Append(data.Privileges.ElementsAs(ctx, &privileges, false)...)
+
+	for _, privilege := range privileges {
+		if isKnown(privilege) && !slices.Contains(objectType.privileges, privilege.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("privileges"),
+				"Invalid privilege",
+				fmt.Sprintf("The %s privilege cannot be granted on objects of type `%s`, valid privileges are: %s.",
+					privilege.ValueString(), objectTypeName, strings.Join(objectType.privileges, ", ")),
+			)
+		}
+	}
+}
+
+func (r *GrantResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(PostgresqlProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected PostgresqlProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.data = data
+}
+
+func (r *GrantResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.data.ConfigUnknown {
+		planUnknownValues(ctx, req, resp)
+	}
+}
+
+func (r *GrantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var dataFromPlan GrantResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataFromPlan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !isKnown(dataFromPlan.Database) {
+		dataFromPlan.Database = types.StringValue(r.data.DatabaseName())
+	}
+
+	if err := r.applyGrant(ctx, nil, &dataFromPlan, false); err != nil {
+		resp.Diagnostics.AddError("DB grant error", fmt.Sprintf("Unable to grant privileges to role '%s', got error: %s", dataFromPlan.Role.ValueString(), err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Successfully granted privileges to Postgresql Role: %s", dataFromPlan.Role.ValueString()))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromPlan)...)
+
+	identity, diags := dataFromPlan.identity(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
+}
+
+func (r *GrantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.data.ConfigUnknown {
+		return
+	}
+
+	var dataFromState GrantResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &dataFromState)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pool, err := r.data.DatabasePool(ctx, dataFromState.Database.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("DB Connection Error", fmt.Sprintf("Unable to connect to database '%s', got error: %s", dataFromState.Database.ValueString(), err))
+		return
+	}
+
+	err = r.readGrant(ctx, pool, &dataFromState)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			resp.Diagnostics.AddWarning("No results returned", fmt.Sprintf("The Postgres role couldn't be found, so it holds no privileges. role: %s", dataFromState.Role.ValueString()))
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("Unable to read the privileges of role '%s', got error: %s", dataFromState.Role.ValueString(), err))
+		}
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromState)...)
+
+	identity, diags := dataFromState.identity(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
+}
+
+// readGrant refreshes the privileges the role of the grant holds on its objects. It returns pgx.ErrNoRows when the
+// role doesn't exist.
+func (r *GrantResource) readGrant(ctx context.Context, pool *pgxpool.Pool, data *GrantResourceModel) error {
+	objectType := grantObjectTypes[data.ObjectType.ValueString()]
+
+	roleOID, err := lookupGranteeOID(ctx, pool, data.Role.ValueString())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+		return fmt.Errorf("unable to look up the role OID: %w", err)
+	}
+
+	objectOIDs, _, missingObjects, err := r.resolveObjects(ctx, pool, data)
+	if err != nil {
+		return fmt.Errorf("unable to look up the granted objects: %w", err)
+	}
+
+	// With no objects to inspect (e.g. an empty schema), the configured privileges are trivially in effect.
+	if len(objectOIDs) == 0 && len(missingObjects) == 0 {
+		return nil
+	}
+
+	privileges, withGrantOption, err := readEffectivePrivileges(ctx, pool, objectType, objectOIDs, roleOID)
+	if err != nil {
+		return fmt.Errorf("SQL query to read privileges encountered an unexpected error, please share this with the developer, error: %w", err)
+	}
+
+	// A privilege can't be held on an object that no longer exists.
+	if len(missingObjects) > 0 {
+		privileges = []string{}
+	}
+
+	privilegesValue, diags := types.SetValueFrom(ctx, types.StringType, privileges)
+	if diags.HasError() {
+		return fmt.Errorf("unable to set privileges: %v", diags)
+	}
+
+	data.Privileges = privilegesValue
+	data.WithGrantOption = types.BoolValue(withGrantOption && len(privileges) > 0)
+
+	return nil
+}
+
+func (r *GrantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var dataFromPlan GrantResourceModel
+	var dataFromState GrantResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataFromPlan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &dataFromState)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.applyGrant(ctx, &dataFromState, &dataFromPlan, false); err != nil {
+		resp.Diagnostics.AddError("DB grant error", fmt.Sprintf("Unable to update privileges of role '%s', got error: %s", dataFromPlan.Role.ValueString(), err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Successfully updated privileges of Postgresql Role: %s", dataFromPlan.Role.ValueString()))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromPlan)...)
+
+	identity, diags := dataFromPlan.identity(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
+}
+
+func (r *GrantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data GrantResourceModel
+
+	// Read Terraform prior state data into the model...
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Revoking everything is the same as granting an empty set of privileges.
+	revokedData := data
+	revokedData.Privileges = types.SetValueMust(types.StringType, nil)
+	revokedData.WithGrantOption = types.BoolValue(false)
+
+	if err := r.applyGrant(ctx, &data, &revokedData, true); err != nil {
+		resp.Diagnostics.AddError("DB revoke error", fmt.Sprintf("Unable to revoke privileges from role '%s', got error: %s", data.Role.ValueString(), err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Successfully revoked privileges from Postgresql Role: %s", data.Role.ValueString()))
+}
+
+// ImportState imports a grant using the identity of an import block. Object names may contain any character, so there
+// is no import ID.
+func (r *GrantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != "" {
+		resp.Diagnostics.AddError(
+			"Unsupported import ID",
+			"Grants can only be imported with the `identity` of an `import` block, which requires Terraform 1.12 or later.",
+		)
+		return
+	}
+
+	var identity GrantResourceIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if identity.Role.ValueString() == "" {
+		resp.Diagnostics.AddError("Invalid import identity", "Expected the role of the grant to be set.")
+		return
+	}
+
+	objectType, ok := grantObjectTypes[identity.ObjectType.ValueString()]
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid import identity",
+			fmt.Sprintf("Expected object_type to be one of `%s`, got: %s", strings.Join(grantObjectTypeNames(), "`, `"), identity.ObjectType),
+		)
+		return
+	}
+
+	if objectType.inSchema && identity.Schema.IsNull() {
+		resp.Diagnostics.AddError("Invalid import identity", fmt.Sprintf("Expected the schema to be set when object_type is `%s`.", identity.ObjectType.ValueString()))
+		return
+	}
+	if !objectType.inSchema && !identity.Schema.IsNull() {
+		resp.Diagnostics.AddError("Invalid import identity", fmt.Sprintf("Expected the schema not to be set when object_type is `%s`.", identity.ObjectType.ValueString()))
+		return
+	}
+
+	if identity.Database.IsNull() {
+		identity.Database = types.StringValue(r.data.DatabaseName())
+	}
+
+	data, diags := seedImportedGrant(identity)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// seedImportedGrant returns the model of a grant imported with identity, whose database is set. Read refreshes the
+// privileges, which are empty when there are no objects to read them from.
+func seedImportedGrant(identity GrantResourceIdentityModel) (GrantResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	objects := types.SetNull(types.StringType)
+	if !identity.Objects.IsNull() {
+		objects, diags = types.SetValue(types.StringType, identity.Objects.Elements())
+	}
+
+	return GrantResourceModel{
+		Database:        identity.Database,
+		Role:            identity.Role,
+		ObjectType:      identity.ObjectType,
+		Schema:          identity.Schema,
+		Objects:         objects,
+		Privileges:      types.SetValueMust(types.StringType, nil),
+		WithGrantOption: types.BoolValue(false),
+		RevokeCascade:   types.BoolValue(false),
+	}, diags
+}
+
+// identity returns the identity of the grant.
+func (m GrantResourceModel) identity(ctx context.Context) (GrantResourceIdentityModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	objects := types.ListNull(types.StringType)
+	if !m.Objects.IsNull() {
+		var objectNames []string
+		diags.Append(m.Objects.ElementsAs(ctx, &objectNames, false)...)
+		slices.Sort(objectNames)
+
+		var d diag.Diagnostics
+		objects, d = types.ListValueFrom(ctx, types.StringType, objectNames)
+		diags.Append(d...)
+	}
+
+	return GrantResourceIdentityModel{
+		Database:   m.Database,
+		Role:       m.Role,
+		ObjectType: m.ObjectType,
+		Schema:     m.Schema,
+		Objects:    objects,
+	}, diags
+}
+
+// applyGrant changes the privileges the role holds on the objects of the grant from those of prior to those of data,
+// within a single transaction. Only the privileges being added are granted and only the privileges being removed are
+// revoked, so that the privileges the role granted on to other roles are left alone. When prior is nil, the privileges
+// the role currently holds are read instead. When ignoreMissing is true, objects and roles that no longer exist are
+// skipped rather than reported as an error, which lets a grant be destroyed after what it refers to is gone.
+func (r *GrantResource) applyGrant(ctx context.Context, prior *GrantResourceModel, data *GrantResourceModel, ignoreMissing bool) error {
+	objectType := grantObjectTypes[data.ObjectType.ValueString()]
+	role := data.Role.ValueString()
+
+	var privileges []string
+	if diags := data.Privileges.ElementsAs(ctx, &privileges, false); diags.HasError() {
+		return fmt.Errorf("unable to read privileges: %v", diags)
+	}
+	slices.Sort(privileges)
+
+	pool, err := r.data.DatabasePool(ctx, data.Database.ValueString())
+	if err != nil {
+		return fmt.Errorf("unable to connect to database '%s': %w", data.Database.ValueString(), err)
+	}
+
+	objectOIDs, quotedObjects, missingObjects, err := r.resolveObjects(ctx, pool, data)
+	if err != nil {
+		return fmt.Errorf("unable to look up the objects: %w", err)
+	}
+	if len(missingObjects) > 0 && !ignoreMissing {
+		return fmt.Errorf("the following %s objects don't exist: %s", data.ObjectType.ValueString(), strings.Join(missingObjects, ", "))
+	}
+
+	roleOID, err := lookupGranteeOID(ctx, pool, role)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("unable to look up role: %w", err)
+		}
+		if ignoreMissing {
+			return nil
+		}
+		return fmt.Errorf("role '%s' doesn't exist", role)
+	}
+
+	// When skipping missing objects, the objects that currently exist are targeted by name, as the schema itself may
+	// be gone.
+	allInSchema := data.Objects.IsNull() && objectType.allInSchemaKeyword != "" && !ignoreMissing
+	if !allInSchema && len(quotedObjects) == 0 {
+		return nil
+	}
+
+	var priorPrivileges []string
+	var priorWithGrantOption bool
+	if prior != nil {
+		if diags := prior.Privileges.ElementsAs(ctx, &priorPrivileges, false); diags.HasError() {
+			return fmt.Errorf("unable to read prior privileges: %v", diags)
+		}
+		priorWithGrantOption = prior.WithGrantOption.ValueBool()
+	} else if len(objectOIDs) > 0 {
+		priorPrivileges, priorWithGrantOption, err = readEffectivePrivileges(ctx, pool, objectType, objectOIDs, roleOID)
+		if err != nil {
+			return fmt.Errorf("unable to read the privileges the role holds: %w", err)
+		}
+	}
+
+	changes := diffGrant(priorPrivileges, priorWithGrantOption, privileges, data.WithGrantOption.ValueBool())
+
+	onClause := objectType.grantOnClause(data.Schema.ValueString(), quotedObjects, allInSchema)
+	statements := changes.statements(onClause, role, data.RevokeCascade.ValueBool())
+
+	return pgx.BeginFunc(ctx, pool, func(txn pgx.Tx) error {
+		for _, statement := range statements {
+			tflog.Info(ctx, statement)
+
+			if _, err := txn.Exec(ctx, statement); err != nil {
+				// 2BP01 is dependent_objects_still_exist, raised when the role granted the privileges on.
+				var pgErr *pgconn.PgError
+				if errors.As(err, &pgErr) && pgErr.Code == "2BP01" {
+					return fmt.Errorf("role '%s' granted some of the privileges being revoked on to other roles, set "+
+						"`revoke_cascade` to revoke them from those roles as well, error executing query '%s': %w", role, statement, err)
+				}
+				return fmt.Errorf("error executing query '%s': %w", statement, err)
+			}
+		}
+
+		if len(changes.revoke) == 0 && len(changes.revokeGrantOption) == 0 {
+			return nil
+		}
+
+		return checkRevokedGrants(ctx, txn, objectType, objectOIDs, roleOID, role, changes)
+	})
+}
+
+// checkRevokedGrants returns an error when the revoked privileges, or their grant option, are still held by the role
+// after being revoked, which happens when other roles granted them too: REVOKE only removes the grants made by the
+// owner of the objects, as which superusers revoke.
+func checkRevokedGrants(ctx context.Context, txn pgx.Tx, objectType grantObjectType, objectOIDs []uint32, roleOID uint32, role string, changes grantChanges) error {
+	rows, err := txn.Query(ctx, objectType.grantorsQuery(), objectOIDs, roleOID, slices.Concat(changes.revoke, changes.revokeGrantOption))
+	if err != nil {
+		return fmt.Errorf("unable to check the revoked privileges: %w", err)
+	}
+
+	var remainingGrants []string
+
+	var privilege, grantor string
+	var isGrantable bool
+	_, err = pgx.ForEachRow(rows, []any{&privilege, &isGrantable, &grantor}, func() error {
+		if slices.Contains(changes.revoke, privilege) {
+			remainingGrants = append(remainingGrants, fmt.Sprintf("%s granted by %s", privilege, grantor))
+		} else if isGrantable {
+			remainingGrants = append(remainingGrants, fmt.Sprintf("the grant option for %s granted by %s", privilege, grantor))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to check the revoked privileges: %w", err)
+	}
+
+	if len(remainingGrants) > 0 {
+		return fmt.Errorf("role '%s' still holds %s, which only those grantors can revoke", role, strings.Join(remainingGrants, ", "))
+	}
+
+	return nil
+}
+
+// lookupGranteeOID returns the OID of role as a grantee of ACLs, which is 0 for PUBLIC. It returns pgx.ErrNoRows when
+// the role doesn't exist.
+func lookupGranteeOID(ctx context.Context, pool *pgxpool.Pool, role string) (uint32, error) {
+	if strings.EqualFold(role, "public") {
+		return 0, nil
+	}
+
+	var roleOID uint32
+	err := pool.QueryRow(ctx, "SELECT oid FROM pg_roles WHERE rolname = $1", role).Scan(&roleOID)
+
+	return roleOID, err
+}
+
+// resolveObjects looks up the objects targeted by the grant, returning their OIDs and quoted, fully qualified names,
+// along with the names of any configured objects that don't exist.
+func (r *GrantResource) resolveObjects(ctx context.Context, pool *pgxpool.Pool, data *GrantResourceModel) ([]uint32, []string, []string, error) {
+	objectType := grantObjectTypes[data.ObjectType.ValueString()]
+
+	var objectNames []string
+	if data.ObjectType.ValueString() == "database" {
+		objectNames = []string{data.Database.ValueString()}
+	} else if !data.Objects.IsNull() {
+		if diags := data.Objects.ElementsAs(ctx, &objectNames, false); diags.HasError() {
+			return nil, nil, nil, fmt.Errorf("unable to read objects: %v", diags)
+		}
+		slices.Sort(objectNames)
+	}
+
+	var oids []uint32
+	var quotedObjects []string
+	var missingObjects []string
+
+	collect := func(args ...any) (int, error) {
+		rows, err := pool.Query(ctx, objectType.resolveQuery, args...)
+		if err != nil {
+			return 0, err
+		}
+
+		found := 0
+		var oid uint32
+		var quotedObject string
+		_, err = pgx.ForEachRow(rows, []any{&oid, &quotedObject}, func() error {
+			found++
+			oids = append(oids, oid)
+			quotedObjects = append(quotedObjects, quotedObject)
+			return nil
+		})
+		return found, err
+	}
+
+	// Every object in the schema.
+	if objectNames == nil {
+		_, err := collect(data.Schema.ValueString(), nil)
+		return oids, quotedObjects, nil, err
+	}
+
+	// Objects are looked up one at a time to find out which ones are missing.
+	for _, objectName := range objectNames {
+		args := []any{[]string{objectName}}
+		if objectType.inSchema {
+			args = []any{data.Schema.ValueString(), []string{objectName}}
+		}
+
+		found, err := collect(args...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if found == 0 {
+			missingObjects = append(missingObjects, objectName)
+		}
+	}
+
+	return oids, quotedObjects, missingObjects, nil
+}
+
+// readEffectivePrivileges returns the privileges the role holds on every one of the given objects, and whether each of
+// them is held with the grant option.
+func readEffectivePrivileges(ctx context.Context, pool *pgxpool.Pool, objectType grantObjectType, objectOIDs []uint32, roleOID uint32) ([]string, bool, error) {
+	rows, err := pool.Query(ctx, objectType.aclQuery(), objectOIDs, roleOID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The same object may be targeted more than once, e.g. by a function's name and by its signature.
+	objects := map[uint32]bool{}
+	for _, objectOID := range objectOIDs {
+		objects[objectOID] = true
+	}
+
+	privilegeObjects := map[string]map[uint32]bool{}
+	withGrantOption := true
+
+	var objectOID uint32
+	var privilege string
+	var isGrantable bool
+	_, err = pgx.ForEachRow(rows, []any{&objectOID, &privilege, &isGrantable}, func() error {
+		// Ignore privileges the provider doesn't manage, such as MAINTAIN on servers prior to Postgres 17.
+		if !slices.Contains(objectType.privileges, privilege) {
+			return nil
+		}
+		if privilegeObjects[privilege] == nil {
+			privilegeObjects[privilege] = map[uint32]bool{}
+		}
+		privilegeObjects[privilege][objectOID] = true
+		withGrantOption = withGrantOption && isGrantable
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Only the privileges held on every object are reported. The slice isn't nil when none are, so that it's stored
+	// as an empty set rather than null, matching `privileges = []`.
+	privileges := []string{}
+	for privilege, grantedObjects := range privilegeObjects {
+		if len(grantedObjects) == len(objects) {
+			privileges = append(privileges, privilege)
+		}
+	}
+	slices.Sort(privileges)
+
+	return privileges, withGrantOption, nil
+}
+
+// grantablePrivileges returns every privilege that can be granted on at least one object type.
+func grantablePrivileges() []string {
+	var privileges []string
+	for _, name := range grantObjectTypeNames() {
+		for _, privilege := range grantObjectTypes[name].privileges {
+			if !slices.Contains(privileges, privilege) {
+				privileges = append(privileges, privilege)
+			}
+		}
+	}
+
+	return privileges
+}
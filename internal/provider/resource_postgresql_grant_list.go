@@ -0,0 +1,322 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"slices"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &GrantListResource{}
+var _ list.ListResourceWithConfigure = &GrantListResource{}
+
+func NewGrantListResource() list.ListResource {
+	return &GrantListResource{}
+}
+
+// GrantListResource lists the privileges granted on the objects of a database.
+type GrantListResource struct {
+	grant GrantResource
+}
+
+type GrantListResourceModel struct {
+	Database             types.String `tfsdk:"database"`
+	Role                 types.String `tfsdk:"role"`
+	ObjectType           types.String `tfsdk:"object_type"`
+	Schema               types.String `tfsdk:"schema"`
+	IncludeSystemObjects types.Bool   `tfsdk:"include_system_objects"`
+}
+
+// grantListQuery selects the roles holding privileges on the objects of the database, from the ACLs of the object
+// types grants can target, along with the schema whose system objects are excluded by default. Routines are listed as
+// functions and procedures, named by signature so that overloaded ones are told apart. The privileges owners hold on
+// their own objects aren't grants, so they're left out.
+const grantListQuery = `
+SELECT DISTINCT object_type, schema_name, object_name, role_name FROM (
+    SELECT
+        g.object_type,
+        g.schema_name,
+        g.object_name,
+        CASE WHEN g.grantee = 0 THEN 'public' ELSE pg_get_userbyid(g.grantee) END AS role_name,
+        g.namespace
+    FROM (
+        SELECT
+            CASE WHEN c.relkind = 'S' THEN 'sequence' ELSE 'table' END AS object_type,
+            n.nspname AS schema_name,
+            c.relname AS object_name,
+            a.grantee,
+            c.relowner AS owner,
+            n.nspname AS namespace
+        FROM pg_class c
+            JOIN pg_namespace n ON n.oid = c.relnamespace
+            CROSS JOIN LATERAL aclexplode(c.relacl) a
+        WHERE c.relkind IN ('r', 'v', 'm', 'f', 'p', 'S')
+        UNION ALL
+        SELECT
+            CASE WHEN p.prokind = 'p' THEN 'procedure' ELSE 'function' END,
+            n.nspname,
+            format('%I(%s)', p.proname, oidvectortypes(p.proargtypes)),
+            a.grantee,
+            p.proowner,
+            n.nspname
+        FROM pg_proc p
+            JOIN pg_namespace n ON n.oid = p.pronamespace
+            CROSS JOIN LATERAL aclexplode(p.proacl) a
+        UNION ALL
+        SELECT
+            CASE WHEN t.typtype = 'd' THEN 'domain' ELSE 'type' END,
+            n.nspname,
+            t.typname,
+            a.grantee,
+            t.typowner,
+            n.nspname
+        FROM pg_type t
+            JOIN pg_namespace n ON n.oid = t.typnamespace
+            CROSS JOIN LATERAL aclexplode(t.typacl) a
+        UNION ALL
+        SELECT 'schema', NULL, n.nspname, a.grantee, n.nspowner, n.nspname
+        FROM pg_namespace n
+            CROSS JOIN LATERAL aclexplode(n.nspacl) a
+        UNION ALL
+        SELECT 'database', NULL, NULL, a.grantee, d.datdba, NULL
+        FROM pg_database d
+            CROSS JOIN LATERAL aclexplode(d.datacl) a
+        WHERE d.datname = current_database()
+        UNION ALL
+        SELECT 'foreign_data_wrapper', NULL, w.fdwname, a.grantee, w.fdwowner, NULL
+        FROM pg_foreign_data_wrapper w
+            CROSS JOIN LATERAL aclexplode(w.fdwacl) a
+        UNION ALL
+        SELECT 'foreign_server', NULL, s.srvname, a.grantee, s.srvowner, NULL
+        FROM pg_foreign_server s
+            CROSS JOIN LATERAL aclexplode(s.srvacl) a
+        UNION ALL
+        SELECT 'large_object', NULL, l.oid::text, a.grantee, l.lomowner, NULL
+        FROM pg_largeobject_metadata l
+            CROSS JOIN LATERAL aclexplode(l.lomacl) a
+    ) g
+    WHERE g.grantee <> g.owner
+) grants`
+
+// grantListObjectTypes returns the object types grants are listed as, which are every object type but routines.
+func grantListObjectTypes() []string {
+	return slices.DeleteFunc(grantObjectTypeNames(), func(objectType string) bool {
+		return objectType == "routine"
+	})
+}
+
+func (r *GrantListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	r.grant.Metadata(ctx, req, resp)
+}
+
+func (r *GrantListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the privileges granted on the objects of a database, as one grant per role and object. " +
+			"Routines are listed as functions and procedures, and the privileges owners hold on their own objects aren't listed.",
+
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "The database whose grants are listed. Defaults to the database the provider is connected to.",
+				Optional:    true,
+			},
+			"role": schema.StringAttribute{
+				Description: "The role holding the listed privileges. Use `public` to list the privileges granted to every role.",
+				Optional:    true,
+			},
+			"object_type": schema.StringAttribute{
+				Description: fmt.Sprintf("The type of the objects the listed privileges are granted on, one of `%s`.", strings.Join(grantListObjectTypes(), "`, `")),
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(grantListObjectTypes()...),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Description: "The schema of the objects the listed privileges are granted on. Grants on objects outside of " +
+					"schemas, such as schemas and databases, aren't listed when it's set.",
+				Optional: true,
+			},
+			"include_system_objects": schema.BoolAttribute{
+				Description: "Determines whether the grants on the system schemas, `information_schema` and the schemas whose " +
+					"names start with `pg_`, and on their objects are listed. Defaults to false.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+func (r *GrantListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.grant.Configure(ctx, req, resp)
+}
+
+func (r *GrantListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var diags diag.Diagnostics
+
+	if r.grant.data.ConfigUnknown {
+		diags.AddError(
+			"Unknown provider configuration",
+			"Grants can't be listed while the provider configuration has values which are only known during apply.",
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var filters GrantListResourceModel
+
+	diags.Append(req.Config.Get(ctx, &filters)...)
+
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	databaseName := filters.Database.ValueString()
+	if filters.Database.IsNull() {
+		databaseName = r.grant.data.DatabaseName()
+	}
+
+	pool, err := r.grant.data.DatabasePool(ctx, databaseName)
+	if err != nil {
+		diags.AddError("DB Connection Error", fmt.Sprintf("Unable to connect to database '%s', got error: %s", databaseName, err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	query, args := buildGrantListQuery(filters, req.Limit)
+
+	grants, err := queryListedGrants(ctx, pool, databaseName, query, args)
+	if err != nil {
+		diags.AddError("DB Query Error", fmt.Sprintf("Unable to list the grants of database '%s', got error: %s", databaseName, err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, grant := range grants {
+			result := req.NewListResult(ctx)
+			result.DisplayName = grant.displayName()
+
+			result.Diagnostics.Append(result.Identity.Set(ctx, grant.identity)...)
+
+			if req.IncludeResource {
+				result.Diagnostics.Append(r.readListedGrant(ctx, pool, result.Resource, grant)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// listedGrant is a grant found by the list resource, on a single object.
+type listedGrant struct {
+	identity GrantResourceIdentityModel
+	object   string
+}
+
+// displayName returns the name of the grant shown by `terraform query`, such as `app on table public.orders`.
+func (g listedGrant) displayName() string {
+	object := g.identity.Database.ValueString()
+	if !g.identity.Schema.IsNull() {
+		object = g.identity.Schema.ValueString() + "." + g.object
+	} else if g.identity.ObjectType.ValueString() != "database" {
+		object = g.object
+	}
+
+	return fmt.Sprintf("%s on %s %s", g.identity.Role.ValueString(), strings.ReplaceAll(g.identity.ObjectType.ValueString(), "_", " "), object)
+}
+
+// queryListedGrants returns the grants of databaseName found by a query returning their object type, schema, object
+// and role.
+func queryListedGrants(ctx context.Context, pool *pgxpool.Pool, databaseName string, query string, args []any) ([]listedGrant, error) {
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error executing query '%s', got error: %w", query, err)
+	}
+
+	var grants []listedGrant
+
+	var objectType, role string
+	var schemaName, object *string
+	_, err = pgx.ForEachRow(rows, []any{&objectType, &schemaName, &object, &role}, func() error {
+		grant := listedGrant{
+			identity: GrantResourceIdentityModel{
+				Database:   types.StringValue(databaseName),
+				Role:       types.StringValue(role),
+				ObjectType: types.StringValue(objectType),
+				Schema:     types.StringPointerValue(schemaName),
+				Objects:    types.ListNull(types.StringType),
+			},
+		}
+
+		// Grants on the database target it by name, rather than through objects.
+		if object != nil {
+			grant.object = *object
+			grant.identity.Objects = types.ListValueMust(types.StringType, []attr.Value{types.StringValue(*object)})
+		}
+
+		grants = append(grants, grant)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error executing query '%s', got error: %w", query, err)
+	}
+
+	return grants, nil
+}
+
+// readListedGrant sets resource to the state of a listed grant, as if it was imported.
+func (r *GrantListResource) readListedGrant(ctx context.Context, pool *pgxpool.Pool, resource *tfsdk.Resource, grant listedGrant) diag.Diagnostics {
+	data, diags := seedImportedGrant(grant.identity)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := r.grant.readGrant(ctx, pool, &data); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			diags.AddError("Role not found", fmt.Sprintf("Role '%s' was dropped while its grants were listed.", data.Role.ValueString()))
+		} else {
+			diags.AddError("DB Query Error", fmt.Sprintf("Unable to read the privileges of role '%s', got error: %s", data.Role.ValueString(), err))
+		}
+		return diags
+	}
+
+	diags.Append(resource.Set(ctx, &data)...)
+
+	return diags
+}
+
+// buildGrantListQuery returns the query listing the object type, schema, object and role of the grants matching
+// filters, along with the query's arguments.
+func buildGrantListQuery(filters GrantListResourceModel, limit int64) (string, []any) {
+	var query listQuery
+
+	if !filters.Role.IsNull() {
+		query.whereArg("role_name =", filters.Role.ValueString())
+	}
+	if !filters.ObjectType.IsNull() {
+		query.whereArg("object_type =", filters.ObjectType.ValueString())
+	}
+	if !filters.Schema.IsNull() {
+		query.whereArg("schema_name =", filters.Schema.ValueString())
+	}
+	if !filters.IncludeSystemObjects.ValueBool() {
+		query.where(`(namespace IS NULL OR (namespace NOT LIKE 'pg\_%' AND namespace <> 'information_schema'))`)
+	}
+
+	return query.build(grantListQuery, "object_type, schema_name, object_name, role_name", limit)
+}
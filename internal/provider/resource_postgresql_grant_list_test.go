@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck/queryfilter"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccGrantListResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// List resources were introduced in Terraform 1.14
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig() + `
+resource "postgresql_role" "grantee" {
+  name = "list_grant_role"
+}
+
+resource "postgresql_schema" "test" {
+  name = "list_grant_schema"
+}
+
+resource "postgresql_grant" "schema" {
+  role        = postgresql_role.grantee.name
+  object_type = "schema"
+  objects     = [postgresql_schema.test.name]
+  privileges  = ["CREATE", "USAGE"]
+}
+
+resource "postgresql_grant" "database" {
+  role        = postgresql_role.grantee.name
+  object_type = "database"
+  privileges  = ["CONNECT"]
+}
+`,
+			},
+			// Test listing the grants of a role
+			{
+				Query: true,
+				Config: providerConfig() + `
+list "postgresql_grant" "test" {
+  provider         = postgresql
+  include_resource = true
+
+  config {
+    role = "list_grant_role"
+  }
+}
+`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("postgresql_grant.test", 2),
+					querycheck.ExpectIdentity("postgresql_grant.test", map[string]knownvalue.Check{
+						"database":    knownvalue.StringExact("terraform_test"),
+						"role":        knownvalue.StringExact("list_grant_role"),
+						"object_type": knownvalue.StringExact("schema"),
+						"schema":      knownvalue.Null(),
+						"objects":     knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("list_grant_schema")}),
+					}),
+					querycheck.ExpectIdentity("postgresql_grant.test", map[string]knownvalue.Check{
+						"database":    knownvalue.StringExact("terraform_test"),
+						"role":        knownvalue.StringExact("list_grant_role"),
+						"object_type": knownvalue.StringExact("database"),
+						"schema":      knownvalue.Null(),
+						"objects":     knownvalue.Null(),
+					}),
+					querycheck.ExpectResourceKnownValues("postgresql_grant.test", queryfilter.ByDisplayName(knownvalue.StringExact("list_grant_role on schema list_grant_schema")), []querycheck.KnownValueCheck{
+						{Path: tfjsonpath.New("privileges"), KnownValue: knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("CREATE"), knownvalue.StringExact("USAGE")})},
+						{Path: tfjsonpath.New("with_grant_option"), KnownValue: knownvalue.Bool(false)},
+					}),
+				},
+			},
+			// Test listing the grants of a role on schemas
+			{
+				Query: true,
+				Config: providerConfig() + `
+list "postgresql_grant" "test" {
+  provider = postgresql
+
+  config {
+    role        = "list_grant_role"
+    object_type = "schema"
+  }
+}
+`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("postgresql_grant.test", 1),
+					querycheck.ExpectResourceDisplayName("postgresql_grant.test", queryfilter.ByDisplayName(knownvalue.StringExact("list_grant_role on schema list_grant_schema")), knownvalue.StringExact("list_grant_role on schema list_grant_schema")),
+				},
+			},
+		},
+	})
+}
+
+func TestBuildGrantListQuery(t *testing.T) {
+	testCases := []struct {
+		testName           string
+		filters            GrantListResourceModel
+		limit              int64
+		expectedConditions string
+		expectedArgs       []any
+	}{
+		{
+			testName: "No filters",
+			filters: GrantListResourceModel{
+				Role:                 types.StringNull(),
+				ObjectType:           types.StringNull(),
+				Schema:               types.StringNull(),
+				IncludeSystemObjects: types.BoolNull(),
+			},
+			expectedConditions: ` WHERE (namespace IS NULL OR (namespace NOT LIKE 'pg\_%' AND namespace <> 'information_schema')) ORDER BY object_type, schema_name, object_name, role_name;`,
+			expectedArgs:       []any{},
+		},
+		{
+			testName: "System objects",
+			filters: GrantListResourceModel{
+				Role:                 types.StringNull(),
+				ObjectType:           types.StringNull(),
+				Schema:               types.StringNull(),
+				IncludeSystemObjects: types.BoolValue(true),
+			},
+			expectedConditions: ` ORDER BY object_type, schema_name, object_name, role_name;`,
+			expectedArgs:       []any{},
+		},
+		{
+			testName: "Every filter",
+			filters: GrantListResourceModel{
+				Role:                 types.StringValue("app"),
+				ObjectType:           types.StringValue("table"),
+				Schema:               types.StringValue("sales"),
+				IncludeSystemObjects: types.BoolValue(true),
+			},
+			limit:              10,
+			expectedConditions: ` WHERE role_name = $1 AND object_type = $2 AND schema_name = $3 ORDER BY object_type, schema_name, object_name, role_name LIMIT $4;`,
+			expectedArgs:       []any{"app", "table", "sales", int64(10)},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			query, args := buildGrantListQuery(testCase.filters, testCase.limit)
+			assert.Equal(t, grantListQuery+testCase.expectedConditions, query)
+			assert.Equal(t, testCase.expectedArgs, args)
+		})
+	}
+}
+
+func TestListedGrantDisplayName(t *testing.T) {
+	testCases := []struct {
+		testName     string
+		objectType   string
+		schema       types.String
+		object       string
+		expectedName string
+	}{
+		{
+			testName:     "Object in a schema",
+			objectType:   "table",
+			schema:       types.StringValue("sales"),
+			object:       "orders",
+			expectedName: "app on table sales.orders",
+		},
+		{
+			testName:     "Object outside of schemas",
+			objectType:   "foreign_server",
+			schema:       types.StringNull(),
+			object:       "remote",
+			expectedName: "app on foreign server remote",
+		},
+		{
+			testName:     "Database",
+			objectType:   "database",
+			schema:       types.StringNull(),
+			expectedName: "app on database analytics",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			grant := listedGrant{
+				identity: GrantResourceIdentityModel{
+					Database:   types.StringValue("analytics"),
+					Role:       types.StringValue("app"),
+					ObjectType: types.StringValue(testCase.objectType),
+					Schema:     testCase.schema,
+				},
+				object: testCase.object,
+			}
+			assert.Equal(t, testCase.expectedName, grant.displayName())
+		})
+	}
+}
@@ -0,0 +1,455 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx/v5"
+	"github.com/ktham/terraform-provider-postgresql/internal/postgresql/pgsql"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GrantRoleResource{}
+var _ resource.ResourceWithModifyPlan = &GrantRoleResource{}
+var _ resource.ResourceWithImportState = &GrantRoleResource{}
+var _ resource.ResourceWithIdentity = &GrantRoleResource{}
+
+func NewGrantRoleResource() resource.Resource {
+	return &GrantRoleResource{}
+}
+
+type GrantRoleResource struct {
+	data PostgresqlProviderData
+}
+
+type GrantRoleResourceModel struct {
+	Role          types.String `tfsdk:"role"`
+	Member        types.String `tfsdk:"member"`
+	AdminOption   types.Bool   `tfsdk:"admin_option"`
+	InheritOption types.Bool   `tfsdk:"inherit_option"`
+	SetOption     types.Bool   `tfsdk:"set_option"`
+}
+
+// GrantRoleResourceIdentityModel is the identity of a role membership, made of the names of the role and its member.
+type GrantRoleResourceIdentityModel struct {
+	Role   types.String `tfsdk:"role"`
+	Member types.String `tfsdk:"member"`
+}
+
+func (r *GrantRoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_grant_role"
+}
+
+func (r *GrantRoleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"role": identityschema.StringAttribute{
+				Description:       "The name of the role being granted.",
+				RequiredForImport: true,
+			},
+			"member": identityschema.StringAttribute{
+				Description:       "The name of the role that is a member of `role`.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *GrantRoleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Postgresql Role Membership. Makes a role a member of another role.",
+
+		Attributes: map[string]schema.Attribute{
+			"role": schema.StringAttribute{
+				Description: "The name of the role being granted.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"member": schema.StringAttribute{
+				Description: "The name of the role that becomes a member of `role`.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"admin_option": schema.BoolAttribute{
+				Description: "Determines whether the member can grant membership in the role to others, and revoke it.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"inherit_option": schema.BoolAttribute{
+				Description: "Determines whether the member inherits the privileges of the role. Defaults to the `inherit` " +
+					"attribute of the member. Requires Postgres 16 or later.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"set_option": schema.BoolAttribute{
+				Description: "Determines whether the member can change to the role using `SET ROLE`. Defaults to true. " +
+					"Requires Postgres 16 or later.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *GrantRoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(PostgresqlProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected PostgresqlProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.data = data
+}
+
+func (r *GrantRoleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.data.ConfigUnknown {
+		planUnknownValues(ctx, req, resp)
+		return
+	}
+
+	// Nothing to check when the resource is being destroyed, or when the provider isn't configured yet.
+	if req.Plan.Raw.IsNull() || r.data.DbPool == nil {
+		return
+	}
+
+	var dataFromConfig GrantRoleResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &dataFromConfig)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The server is only queried when the options requiring Postgres 16 are set.
+	if dataFromConfig.InheritOption.IsNull() && dataFromConfig.SetOption.IsNull() {
+		return
+	}
+
+	isPostgres16, version, diags := r.data.IsVersionAtLeast(ctx, 16)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || isPostgres16 {
+		return
+	}
+
+	if !dataFromConfig.InheritOption.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("inherit_option"),
+			"Unsupported Postgres version",
+			fmt.Sprintf("Setting `inherit_option` requires Postgres 16 or later, the server is running Postgres %s.", version),
+		)
+	}
+	if !dataFromConfig.SetOption.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("set_option"),
+			"Unsupported Postgres version",
+			fmt.Sprintf("Setting `set_option` requires Postgres 16 or later, the server is running Postgres %s.", version),
+		)
+	}
+}
+
+func (r *GrantRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var dataFromPlan GrantRoleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataFromPlan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	isPostgres16, _, diags := r.data.IsVersionAtLeast(ctx, 16)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	grantRoleSql := buildGrantRoleStatement(dataFromPlan.Role.ValueString(), dataFromPlan.Member.ValueString(), dataFromPlan.GetGrantOptions(isPostgres16))
+
+	tflog.Info(ctx, grantRoleSql)
+
+	if _, err := r.data.DbPool.Exec(ctx, grantRoleSql); err != nil {
+		resp.Diagnostics.AddError("DB role grant error", fmt.Sprintf("Error executing query '%s', got error: %s", grantRoleSql, err))
+		return
+	}
+
+	if err := r.readMembership(ctx, &dataFromPlan); err != nil {
+		resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("Unable to read back the role membership, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Successfully granted Postgresql Role %s to %s", dataFromPlan.Role.ValueString(), dataFromPlan.Member.ValueString()))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromPlan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, GrantRoleResourceIdentityModel{Role: dataFromPlan.Role, Member: dataFromPlan.Member})...)
+}
+
+func (r *GrantRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.data.ConfigUnknown {
+		return
+	}
+
+	var dataFromState GrantRoleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &dataFromState)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.readMembership(ctx, &dataFromState)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			resp.Diagnostics.AddWarning("No results returned", fmt.Sprintf("The Postgres role membership couldn't be found. role: %s, member: %s", dataFromState.Role.ValueString(), dataFromState.Member.ValueString()))
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("SQL query to read role membership encountered an unexpected error, please share this with the developer, error: %s", err))
+		}
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, GrantRoleResourceIdentityModel{Role: dataFromState.Role, Member: dataFromState.Member})...)
+}
+
+func (r *GrantRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var dataFromPlan GrantRoleResourceModel
+	var dataFromState GrantRoleResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataFromPlan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &dataFromState)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role := dataFromPlan.Role.ValueString()
+	member := dataFromPlan.Member.ValueString()
+	isPostgres16, _, diags := r.data.IsVersionAtLeast(ctx, 16)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Options being turned on are granted, while options being turned off have to be revoked.
+	var statements []string
+
+	options := []struct {
+		name      string
+		planned   types.Bool
+		current   types.Bool
+		supported bool
+	}{
+		{name: "ADMIN", planned: dataFromPlan.AdminOption, current: dataFromState.AdminOption, supported: true},
+		{name: "INHERIT", planned: dataFromPlan.InheritOption, current: dataFromState.InheritOption, supported: isPostgres16},
+		{name: "SET", planned: dataFromPlan.SetOption, current: dataFromState.SetOption, supported: isPostgres16},
+	}
+
+	for _, option := range options {
+		if !option.supported || !isKnown(option.planned) || option.planned.Equal(option.current) {
+			continue
+		}
+
+		if option.planned.ValueBool() {
+			grantOption := fmt.Sprintf("%s TRUE", option.name)
+			if !isPostgres16 {
+				grantOption = "ADMIN OPTION"
+			}
+			statements = append(statements, buildGrantRoleStatement(role, member, []string{grantOption}))
+		} else {
+			statements = append(statements, fmt.Sprintf("REVOKE %s OPTION FOR %s FROM %s;", option.name, pgsql.Identifier(role), pgsql.Identifier(member)))
+		}
+	}
+
+	err := pgx.BeginFunc(ctx, r.data.DbPool, func(txn pgx.Tx) error {
+		for _, statement := range statements {
+			tflog.Info(ctx, statement)
+
+			if _, err := txn.Exec(ctx, statement); err != nil {
+				return fmt.Errorf("error executing query '%s': %w", statement, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("DB role grant update error", err.Error())
+		return
+	}
+
+	if err := r.readMembership(ctx, &dataFromPlan); err != nil {
+		resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("Unable to read back the role membership, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Successfully altered membership of Postgresql Role %s in %s", member, role))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromPlan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, GrantRoleResourceIdentityModel{Role: dataFromPlan.Role, Member: dataFromPlan.Member})...)
+}
+
+func (r *GrantRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data GrantRoleResourceModel
+
+	// Read Terraform prior state data into the model...
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	revokeRoleSql := fmt.Sprintf("REVOKE %s FROM %s;", pgsql.Identifier(data.Role.ValueString()), pgsql.Identifier(data.Member.ValueString()))
+
+	tflog.Info(ctx, revokeRoleSql)
+
+	if _, err := r.data.DbPool.Exec(ctx, revokeRoleSql); err != nil {
+		resp.Diagnostics.AddError("DB role revoke error", fmt.Sprintf("Error executing query '%s', got error: %s", revokeRoleSql, err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Successfully revoked Postgresql Role %s from %s", data.Role.ValueString(), data.Member.ValueString()))
+}
+
+// ImportState imports a role membership using the identity of an import block. Role names may contain any character,
+// so there is no import ID.
+func (r *GrantRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != "" {
+		resp.Diagnostics.AddError(
+			"Unsupported import ID",
+			"Role memberships can only be imported with the `identity` of an `import` block, which requires Terraform 1.12 or later.",
+		)
+		return
+	}
+
+	var identity GrantRoleResourceIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if identity.Role.ValueString() == "" || identity.Member.ValueString() == "" {
+		resp.Diagnostics.AddError("Invalid import identity", "Expected the role and the member of the role membership to be set.")
+		return
+	}
+
+	// Read refreshes the options, or removes the membership if it doesn't exist.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role"), identity.Role)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member"), identity.Member)...)
+}
+
+// readMembership refreshes the options of the membership from pg_auth_members. It returns pgx.ErrNoRows if the member
+// isn't a member of the role. As Postgres 16 records a membership once per grantor, an option is reported as set if
+// any of the grants sets it.
+func (r *GrantRoleResource) readMembership(ctx context.Context, data *GrantRoleResourceModel) error {
+	isPostgres16, _, err := r.data.isVersionAtLeast(ctx, 16)
+	if err != nil {
+		return err
+	}
+
+	optionColumns := "NULL::boolean, NULL::boolean"
+	if isPostgres16 {
+		optionColumns = "bool_or(m.inherit_option), bool_or(m.set_option)"
+	}
+
+	membershipSql := fmt.Sprintf(`
+SELECT
+    bool_or(m.admin_option),
+    %s
+FROM
+    pg_auth_members m
+    JOIN pg_roles r ON r.oid = m.roleid
+    JOIN pg_roles u ON u.oid = m.member
+WHERE
+    r.rolname = $1
+    AND u.rolname = $2
+HAVING
+    count(*) > 0;`, optionColumns)
+
+	var adminOption bool
+	var inheritOption *bool
+	var setOption *bool
+
+	err = r.data.DbPool.QueryRow(ctx, membershipSql, data.Role.ValueString(), data.Member.ValueString()).Scan(&adminOption, &inheritOption, &setOption)
+	if err != nil {
+		return err
+	}
+
+	data.AdminOption = types.BoolValue(adminOption)
+	data.InheritOption = types.BoolPointerValue(inheritOption)
+	data.SetOption = types.BoolPointerValue(setOption)
+
+	return nil
+}
+
+// GetGrantOptions returns the options of the `GRANT role TO member WITH ...` statement. Prior to Postgres 16, only the
+// admin option is supported.
+func (r *GrantRoleResourceModel) GetGrantOptions(isPostgres16 bool) []string {
+	if !isPostgres16 {
+		if r.AdminOption.ValueBool() {
+			return []string{"ADMIN OPTION"}
+		}
+		return nil
+	}
+
+	options := []string{fmt.Sprintf("ADMIN %t", r.AdminOption.ValueBool())}
+
+	if isKnown(r.InheritOption) {
+		options = append(options, fmt.Sprintf("INHERIT %t", r.InheritOption.ValueBool()))
+	}
+	if isKnown(r.SetOption) {
+		options = append(options, fmt.Sprintf("SET %t", r.SetOption.ValueBool()))
+	}
+
+	return options
+}
+
+// buildGrantRoleStatement returns a statement granting membership in role to member with the given options.
+func buildGrantRoleStatement(role string, member string, options []string) string {
+	grantRoleSql := fmt.Sprintf("GRANT %s TO %s", pgsql.Identifier(role), pgsql.Identifier(member))
+
+	if len(options) > 0 {
+		grantRoleSql += " WITH " + strings.Join(options, ", ")
+	}
+
+	return grantRoleSql + ";"
+}
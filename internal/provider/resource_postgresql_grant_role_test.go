@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccGrantRoleResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test granting role membership
+			{
+				Config: providerConfig() + testAccGrantRoleResourceConfig(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_grant_role.test", "role", "grant_role_group"),
+					resource.TestCheckResourceAttr("postgresql_grant_role.test", "member", "grant_role_member"),
+					resource.TestCheckResourceAttr("postgresql_grant_role.test", "admin_option", "false"),
+				),
+			},
+			// Test updating the admin option
+			{
+				Config: providerConfig() + testAccGrantRoleResourceConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_grant_role.test", "admin_option", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccGrantRoleResourceIdentity(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Resource identity was introduced in Terraform 1.12
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig() + testAccGrantRoleResourceConfig(true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("postgresql_grant_role.test", map[string]knownvalue.Check{
+						"role":   knownvalue.StringExact("grant_role_group"),
+						"member": knownvalue.StringExact("grant_role_member"),
+					}),
+				},
+			},
+			// Test role membership import with an import block identifying the membership with its identity
+			{
+				ResourceName:    "postgresql_grant_role.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func testAccGrantRoleResourceConfig(adminOption bool) string {
+	return fmt.Sprintf(`
+resource "postgresql_role" "group" {
+  name = "grant_role_group"
+}
+
+resource "postgresql_role" "member" {
+  name = "grant_role_member"
+}
+
+resource "postgresql_grant_role" "test" {
+  role         = postgresql_role.group.name
+  member       = postgresql_role.member.name
+  admin_option = %t
+}
+`, adminOption)
+}
+
+func TestBuildGrantRoleStatement(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		model          GrantRoleResourceModel
+		isPostgres16   bool
+		expectedOutput string
+	}{
+		{
+			testName:       "Without options prior to Postgres 16",
+			model:          GrantRoleResourceModel{Role: types.StringValue("app"), Member: types.StringValue("alice"), AdminOption: types.BoolValue(false)},
+			expectedOutput: `GRANT "app" TO "alice";`,
+		},
+		{
+			testName:       "Admin option prior to Postgres 16",
+			model:          GrantRoleResourceModel{Role: types.StringValue("app"), Member: types.StringValue("alice"), AdminOption: types.BoolValue(true)},
+			expectedOutput: `GRANT "app" TO "alice" WITH ADMIN OPTION;`,
+		},
+		{
+			testName: "Every option on Postgres 16",
+			model: GrantRoleResourceModel{
+				Role:          types.StringValue("app"),
+				Member:        types.StringValue("alice"),
+				AdminOption:   types.BoolValue(false),
+				InheritOption: types.BoolValue(false),
+				SetOption:     types.BoolValue(true),
+			},
+			isPostgres16:   true,
+			expectedOutput: `GRANT "app" TO "alice" WITH ADMIN false, INHERIT false, SET true;`,
+		},
+		{
+			testName: "Unknown options on Postgres 16 are left to the server",
+			model: GrantRoleResourceModel{
+				Role:          types.StringValue("app"),
+				Member:        types.StringValue("alice"),
+				AdminOption:   types.BoolValue(true),
+				InheritOption: types.BoolUnknown(),
+				SetOption:     types.BoolUnknown(),
+			},
+			isPostgres16:   true,
+			expectedOutput: `GRANT "app" TO "alice" WITH ADMIN true;`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			actualOutput := buildGrantRoleStatement(testCase.model.Role.ValueString(), testCase.model.Member.ValueString(), testCase.model.GetGrantOptions(testCase.isPostgres16))
+
+			assert.Equal(t, testCase.expectedOutput, actualOutput)
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccGrantResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test granting on a schema
+			{
+				Config: providerConfig() + testAccGrantResourceConfig(`["USAGE"]`, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_grant.schema", "database", "terraform_test"),
+					resource.TestCheckResourceAttr("postgresql_grant.schema", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("postgresql_grant.schema", "privileges.*", "USAGE"),
+					resource.TestCheckResourceAttr("postgresql_grant.schema", "with_grant_option", "false"),
+					resource.TestCheckResourceAttr("postgresql_grant.tables", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("postgresql_grant.tables", "privileges.*", "SELECT"),
+					resource.TestCheckTypeSetElemAttr("postgresql_grant.tables", "privileges.*", "INSERT"),
+				),
+			},
+			// Test updating privileges
+			{
+				Config: providerConfig() + testAccGrantResourceConfig(`["CREATE", "USAGE"]`, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_grant.schema", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("postgresql_grant.schema", "privileges.*", "CREATE"),
+					resource.TestCheckResourceAttr("postgresql_grant.schema", "with_grant_option", "true"),
+				),
+			},
+			// Test revoking every privilege with an empty set, which must be read back as an empty set rather than null
+			{
+				Config: providerConfig() + testAccGrantResourceConfig(`[]`, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_grant.schema", "privileges.#", "0"),
+					resource.TestCheckResourceAttr("postgresql_grant.schema", "with_grant_option", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccGrantResourceIdentity(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Resource identity was introduced in Terraform 1.12
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig() + testAccGrantResourceConfig(`["USAGE"]`, false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("postgresql_grant.schema", map[string]knownvalue.Check{
+						"database":    knownvalue.StringExact("terraform_test"),
+						"role":        knownvalue.StringExact("grant_role"),
+						"object_type": knownvalue.StringExact("schema"),
+						"schema":      knownvalue.Null(),
+						"objects":     knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("grant_schema")}),
+					}),
+					statecheck.ExpectIdentity("postgresql_grant.tables", map[string]knownvalue.Check{
+						"database":    knownvalue.StringExact("terraform_test"),
+						"role":        knownvalue.StringExact("grant_role"),
+						"object_type": knownvalue.StringExact("table"),
+						"schema":      knownvalue.StringExact("grant_schema"),
+						"objects":     knownvalue.Null(),
+					}),
+				},
+			},
+			// Test grant import with an import block identifying the grant with its identity
+			{
+				ResourceName:    "postgresql_grant.schema",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+			{
+				ResourceName:    "postgresql_grant.tables",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestAccGrantResourceRegranted(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The grantee grants the privilege on to another role with the grant option
+			{
+				Config: providerConfig() + testAccGrantResourceRegrantedConfig(`["USAGE"]`, false),
+				Check:  testAccExec(`SET ROLE regrant_role; GRANT USAGE ON SCHEMA regrant_schema TO regrant_other; RESET ROLE;`),
+			},
+			// Test adding a privilege leaves the re-granted one alone
+			{
+				Config: providerConfig() + testAccGrantResourceRegrantedConfig(`["CREATE", "USAGE"]`, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_grant.schema", "privileges.#", "2"),
+					resource.TestCheckResourceAttr("postgresql_grant.schema", "with_grant_option", "true"),
+				),
+			},
+			// Test revoking the re-granted privilege requires revoke_cascade
+			{
+				Config:      providerConfig() + testAccGrantResourceRegrantedConfig(`["CREATE"]`, false),
+				ExpectError: regexp.MustCompile("revoke_cascade"),
+			},
+			{
+				Config: providerConfig() + testAccGrantResourceRegrantedConfig(`["CREATE"]`, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_grant.schema", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("postgresql_grant.schema", "privileges.*", "CREATE"),
+				),
+			},
+		},
+	})
+}
+
+func testAccGrantResourceRegrantedConfig(privileges string, revokeCascade bool) string {
+	return fmt.Sprintf(`
+resource "postgresql_role" "grantee" {
+  name = "regrant_role"
+}
+
+resource "postgresql_role" "other" {
+  name = "regrant_other"
+}
+
+resource "postgresql_schema" "test" {
+  name = "regrant_schema"
+}
+
+resource "postgresql_grant" "schema" {
+  role              = postgresql_role.grantee.name
+  object_type       = "schema"
+  objects           = [postgresql_schema.test.name]
+  privileges        = %[1]s
+  with_grant_option = true
+  revoke_cascade    = %[2]t
+}
+`, privileges, revokeCascade)
+}
+
+func testAccGrantResourceConfig(schemaPrivileges string, withGrantOption bool) string {
+	return fmt.Sprintf(`
+resource "postgresql_role" "grantee" {
+  name = "grant_role"
+}
+
+resource "postgresql_schema" "test" {
+  name         = "grant_schema"
+  drop_cascade = true
+}
+
+resource "postgresql_grant" "schema" {
+  role              = postgresql_role.grantee.name
+  object_type       = "schema"
+  objects           = [postgresql_schema.test.name]
+  privileges        = %[1]s
+  with_grant_option = %[2]t
+}
+
+resource "postgresql_grant" "tables" {
+  role        = postgresql_role.grantee.name
+  object_type = "table"
+  schema      = postgresql_schema.test.name
+  privileges  = ["SELECT", "INSERT"]
+}
+`, schemaPrivileges, withGrantOption)
+}
+
+func TestGrantResourceModelIdentity(t *testing.T) {
+	testCases := []struct {
+		testName        string
+		objects         types.Set
+		expectedObjects types.List
+	}{
+		{
+			testName:        "Every object of the schema",
+			objects:         types.SetNull(types.StringType),
+			expectedObjects: types.ListNull(types.StringType),
+		},
+		{
+			testName: "Sorted objects",
+			objects: types.SetValueMust(types.StringType, []attr.Value{
+				types.StringValue("orders"),
+				types.StringValue("customers"),
+			}),
+			expectedObjects: types.ListValueMust(types.StringType, []attr.Value{
+				types.StringValue("customers"),
+				types.StringValue("orders"),
+			}),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			data := GrantResourceModel{
+				Database:   types.StringValue("app"),
+				Role:       types.StringValue("reader"),
+				ObjectType: types.StringValue("table"),
+				Schema:     types.StringValue("sales"),
+				Objects:    testCase.objects,
+			}
+
+			identity, diags := data.identity(context.Background())
+			require.False(t, diags.HasError(), "%v", diags)
+			assert.Equal(t, GrantResourceIdentityModel{
+				Database:   types.StringValue("app"),
+				Role:       types.StringValue("reader"),
+				ObjectType: types.StringValue("table"),
+				Schema:     types.StringValue("sales"),
+				Objects:    testCase.expectedObjects,
+			}, identity)
+		})
+	}
+}
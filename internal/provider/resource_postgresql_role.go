@@ -5,6 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -12,17 +16,28 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ktham/terraform-provider-postgresql/internal/postgresql"
+	"github.com/ktham/terraform-provider-postgresql/internal/postgresql/pgsql"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &RoleResource{}
 var _ resource.ResourceWithImportState = &RoleResource{}
+var _ resource.ResourceWithModifyPlan = &RoleResource{}
+var _ resource.ResourceWithIdentity = &RoleResource{}
+var _ resource.ResourceWithUpgradeState = &RoleResource{}
 
 func NewRoleResource() resource.Resource {
 	return &RoleResource{}
@@ -35,22 +50,44 @@ type RoleResource struct {
 type RoleResourceModel struct {
 	Oid                    types.Int64  `tfsdk:"oid"`
 	Name                   types.String `tfsdk:"name"`
+	RenameInPlace          types.Bool   `tfsdk:"rename_in_place"`
 	BypassRowLevelSecurity types.Bool   `tfsdk:"bypass_row_level_security"`
 	CanLogin               types.Bool   `tfsdk:"can_login"`
 	ConnectionLimit        types.Int32  `tfsdk:"connection_limit"`
+	CreateDatabase         types.Bool   `tfsdk:"create_database"`
 	CreateRole             types.Bool   `tfsdk:"create_role"`
 	Inherit                types.Bool   `tfsdk:"inherit"`
 	Replication            types.Bool   `tfsdk:"replication"`
 	Superuser              types.Bool   `tfsdk:"superuser"`
+	ValidUntil             types.String `tfsdk:"valid_until"`
+	Password               types.String `tfsdk:"password"`
+	PasswordWo             types.String `tfsdk:"password_wo"`
+	PasswordWoVersion      types.Int64  `tfsdk:"password_wo_version"`
+	Parameters             types.Map    `tfsdk:"parameters"`
+	DatabaseParameters     types.Map    `tfsdk:"database_parameters"`
+	DropBehavior           types.Object `tfsdk:"drop_behavior"`
+}
+
+type RoleDropBehaviorModel struct {
+	ReassignOwnedTo types.String `tfsdk:"reassign_owned_to"`
+	DropOwned       types.Bool   `tfsdk:"drop_owned"`
 }
 
 func (r *RoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_role"
+	// The OID of the identity changes when a role re-created outside of Terraform is adopted, and its system identifier
+	// is filled in once it can be determined.
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+func (r *RoleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = serverObjectIdentitySchema("role")
 }
 
 func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Postgresql Role",
+		Version:     roleSchemaVersion,
 
 		Attributes: map[string]schema.Attribute{
 			"oid": schema.Int64Attribute{
@@ -66,11 +103,24 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Description: "The name of the Postgresql role.",
 				Required:    true,
 				// The impact of a role re-name can have a problematic impact on downstream dependencies of this role,
-				// so we won't support in-place update of the role's name.
+				// so the role is replaced on rename unless `rename_in_place` is explicitly enabled.
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(
+						renameRequiresReplace,
+						"The role is replaced when its name changes, unless rename_in_place is enabled.",
+						"The role is replaced when its name changes, unless `rename_in_place` is enabled.",
+					),
 				},
 			},
+			"rename_in_place": schema.BoolAttribute{
+				Description: "Determines whether a change of `name` renames the role with `ALTER ROLE ... RENAME TO`, keeping its " +
+					"OID, ownerships, memberships and privileges, rather than dropping and re-creating it. A password stored as " +
+					"an MD5 hash is cleared by the server on rename, as the role name is part of the hash, unless `password` " +
+					"or `password_wo` is set so that it can be set again.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 			"bypass_row_level_security": schema.BoolAttribute{
 				Description: "Determines whether a role bypasses every row-level security (RLS) policy.",
 				Optional:    true,
@@ -92,6 +142,12 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					int32validator.AtLeast(-1),
 				},
 			},
+			"create_database": schema.BoolAttribute{
+				Description: "Determines whether the role will be permitted to create databases.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 			"create_role": schema.BoolAttribute{
 				Description: "Determines whether the role will be permitted to create, alter, drop, comment on, and change the security label for other roles.",
 				Optional:    true,
@@ -116,6 +172,86 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"valid_until": schema.StringAttribute{
+				Description: "The date and time after which the role's password is no longer valid, as an RFC 3339 timestamp " +
+					"such as `2030-01-01T00:00:00Z`. `infinity` (the default) means the password never expires.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("infinity"),
+				Validators: []validator.String{
+					validUntilValidator{},
+				},
+			},
+			"password": schema.StringAttribute{
+				Description: "The password of the role. The password is hashed client-side using SCRAM-SHA-256, or MD5 when " +
+					"the server's `password_encryption` setting requires it, before being sent to the server. An already hashed " +
+					"password is used as-is. The password is stored in the Terraform state, use `password_wo` to avoid that.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("password_wo")),
+				},
+			},
+			"password_wo": schema.StringAttribute{
+				Description: "The password of the role, as a write-only attribute that is never stored in the Terraform state. " +
+					"It's hashed the same way as `password`. Requires Terraform 1.11 or later.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("password_wo_version")),
+				},
+			},
+			"password_wo_version": schema.Int64Attribute{
+				Description: "The version of `password_wo`. As write-only attributes are not stored in the state, the password " +
+					"is only updated when this version changes.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("password_wo")),
+				},
+			},
+			"parameters": schema.MapAttribute{
+				Description: "Configuration parameters set for the role in all databases, e.g. `statement_timeout`, as set by " +
+					"`ALTER ROLE ... SET`. Values are compared the way the server interprets them, so `1min` and `60s` are " +
+					"equivalent. Once set, parameters set outside of Terraform are removed.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"database_parameters": schema.MapAttribute{
+				Description: "Configuration parameters set for the role in specific databases, as set by `ALTER ROLE ... IN " +
+					"DATABASE ... SET`, keyed by database name. They take precedence over `parameters`. Only the databases " +
+					"listed are managed.",
+				Optional:    true,
+				ElementType: types.MapType{ElemType: types.StringType},
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"drop_behavior": schema.SingleNestedAttribute{
+				Description: "Determines what happens to the objects owned by the role, and the privileges granted to it, when " +
+					"the role is dropped. They are handled in every database which allows connections, as DROP ROLE fails as " +
+					"long as the role owns objects or holds privileges in any database.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"reassign_owned_to": schema.StringAttribute{
+						Description: "The role that becomes the owner of the objects owned by the role, with `REASSIGN OWNED BY`.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+							stringvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("drop_owned")),
+						},
+					},
+					"drop_owned": schema.BoolAttribute{
+						Description: "Determines whether the objects owned by the role are dropped, and the privileges granted to it " +
+							"revoked, with `DROP OWNED BY`. When combined with `reassign_owned_to`, the objects are reassigned first, " +
+							"so only the privileges are revoked.",
+						Optional: true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -166,19 +302,39 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		}
 	}()
 
-	createRoleSql := fmt.Sprintf("CREATE ROLE %s WITH %s;", dataFromPlan.Name.ValueString(), dataFromPlan.GetOptionsString(r))
+	options := dataFromPlan.GetOptionsString(r)
+
+	password, diags := getConfiguredPassword(ctx, req.Config, dataFromPlan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var passwordOption string
+	if !password.IsNull() {
+		passwordOption, err = r.getPasswordOptionString(ctx, txn, dataFromPlan.Name.ValueString(), password)
+		if err != nil {
+			resp.Diagnostics.AddError("DB role creation error", fmt.Sprintf("Unable to hash the role's password, got error: %s", err))
+			return
+		}
+		options += " " + passwordOption
+		ctx = tflog.MaskMessageStrings(ctx, passwordOption)
+	}
+
+	createRoleSql := fmt.Sprintf("CREATE ROLE %s WITH %s;", pgsql.Identifier(dataFromPlan.Name.ValueString()), options)
 
 	tflog.Info(ctx, createRoleSql)
 
 	if _, err = txn.Exec(ctx, createRoleSql); err != nil {
-		resp.Diagnostics.AddError("DB role creation error", fmt.Sprintf("Error executing query '%s', got error: %s", createRoleSql, err))
+		resp.Diagnostics.AddError("DB role creation error", fmt.Sprintf("Error executing query '%s', got error: %s", redactPasswordOption(createRoleSql, passwordOption), err))
 		return
 	}
 
 	var roleOID uint32
-	selectOidQuery := fmt.Sprintf("SELECT oid FROM pg_roles WHERE rolname = '%s'", dataFromPlan.Name.ValueString())
+	selectOidQuery := "SELECT oid FROM pg_roles WHERE rolname = $1"
 
-	err = txn.QueryRow(ctx, selectOidQuery).Scan(&roleOID)
+	err = txn.QueryRow(ctx, selectOidQuery, dataFromPlan.Name.ValueString()).Scan(&roleOID)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to retrieve role OID", fmt.Sprintf("Error retrieving role OID with query `%s`, got error: %s", selectOidQuery, err))
 		return
@@ -186,6 +342,19 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	dataFromPlan.Oid = types.Int64Value(int64(roleOID))
 
+	// The role has no parameters yet.
+	priorData := RoleResourceModel{
+		Parameters:         types.MapNull(types.StringType),
+		DatabaseParameters: types.MapNull(types.MapType{ElemType: types.StringType}),
+	}
+
+	resp.Diagnostics.Append(applyAllRoleParameters(ctx, txn, priorData, dataFromPlan)...)
+
+	if resp.Diagnostics.HasError() {
+		err = errors.New("unable to set the role's parameters")
+		return
+	}
+
 	err = txn.Commit(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("DB transaction error", fmt.Sprintf("Error committing DB transaction, got error: %s", err))
@@ -196,9 +365,15 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromPlan)...)
+
+	resp.Diagnostics.Append(r.data.setServerObjectIdentity(ctx, resp.Identity, dataFromPlan.Oid)...)
 }
 
 func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.data.ConfigUnknown {
+		return
+	}
+
 	var dataFromState RoleResourceModel
 
 	// Read Terraform prior state data into the model
@@ -208,69 +383,124 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// Query for the actual state of the role from the database
-	roleSql := fmt.Sprintf(`
+	err := r.readRole(ctx, &dataFromState)
+
+	// The role may have been dropped and re-created with the same name outside of Terraform, giving it another OID.
+	if errors.Is(err, pgx.ErrNoRows) {
+		var roleOID uint32
+		lookupErr := r.data.DbPool.QueryRow(ctx, "SELECT oid FROM pg_roles WHERE rolname = $1", dataFromState.Name.ValueString()).Scan(&roleOID)
+
+		switch {
+		case lookupErr == nil && r.data.OnOIDMismatch == OnOIDMismatchReplace:
+			// The prior state is kept for ModifyPlan to plan the replacement of the re-created role.
+			resp.Diagnostics.AddWarning(
+				"Role re-created outside of Terraform",
+				fmt.Sprintf("Role '%s' was dropped and re-created outside of Terraform, its OID changed from %d to %d. It will be replaced, "+
+					"as on_oid_mismatch is set to replace.", dataFromState.Name.ValueString(), dataFromState.Oid.ValueInt64(), roleOID),
+			)
+			return
+		case lookupErr == nil:
+			resp.Diagnostics.AddWarning(
+				"Role re-created outside of Terraform",
+				fmt.Sprintf("Role '%s' was dropped and re-created outside of Terraform, its OID changed from %d to %d. The re-created role "+
+					"is now managed instead.", dataFromState.Name.ValueString(), dataFromState.Oid.ValueInt64(), roleOID),
+			)
+			dataFromState.Oid = types.Int64Value(int64(roleOID))
+			err = r.readRole(ctx, &dataFromState)
+		case !errors.Is(lookupErr, pgx.ErrNoRows):
+			err = lookupErr
+		}
+	}
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			resp.Diagnostics.AddWarning("No results returned", fmt.Sprintf("The Postgres role couldn't be found. role: %s", dataFromState.Name.ValueString()))
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("SQL query to read role encountered an unexpected error, please share this with the developer, error: %s", err))
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(r.readParameters(ctx, &dataFromState)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromState)...)
+
+	resp.Diagnostics.Append(r.data.setServerObjectIdentity(ctx, resp.Identity, dataFromState.Oid)...)
+}
+
+// readRole refreshes the attributes of the model that are stored in pg_roles from the role identified by the model's
+// OID. It returns pgx.ErrNoRows if the role no longer exists.
+func (r *RoleResource) readRole(ctx context.Context, data *RoleResourceModel) error {
+	roleSql := `
 SELECT
     rolbypassrls,
     rolcanlogin,
     rolconnlimit,
+    rolcreatedb,
     rolcreaterole,
     rolinherit,
     rolname,
     rolreplication,
-    rolsuper
+    rolsuper,
+    rolvaliduntil
 FROM 
     pg_roles
 WHERE 
-    oid = %d;`, dataFromState.Oid.ValueInt64())
+    oid = $1;`
 
 	var bypassRowLevelSecurity bool
 	var canLogin bool
 	var connectionLimit int32
+	var createDatabase bool
 	var createRole bool
 	var inherit bool
 	var name string
 	var replication bool
 	var superuser bool
+	var validUntil pgtype.Timestamptz
 
-	err := r.data.DbPool.QueryRow(ctx, roleSql).Scan(
+	err := r.data.DbPool.QueryRow(ctx, roleSql, data.Oid.ValueInt64()).Scan(
 		&bypassRowLevelSecurity,
 		&canLogin,
 		&connectionLimit,
+		&createDatabase,
 		&createRole,
 		&inherit,
 		&name,
 		&replication,
 		&superuser,
+		&validUntil,
 	)
-
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			resp.Diagnostics.AddWarning("No results returned", fmt.Sprintf("The Postgres role couldn't be found. role: %s", dataFromState.Name.ValueString()))
-			resp.State.RemoveResource(ctx)
-		} else {
-			resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("SQL query to read role encountered an unexpected error, please share this with the developer, query=`%s`, error: %s", roleSql, err))
-			return
-		}
+		return err
 	}
 
-	dataFromState.BypassRowLevelSecurity = types.BoolValue(bypassRowLevelSecurity)
-	dataFromState.CanLogin = types.BoolValue(canLogin)
-	dataFromState.ConnectionLimit = types.Int32Value(connectionLimit)
-	dataFromState.CreateRole = types.BoolValue(createRole)
-	dataFromState.Inherit = types.BoolValue(inherit)
-	dataFromState.Replication = types.BoolValue(replication)
-	dataFromState.Superuser = types.BoolValue(superuser)
-
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromState)...)
+	data.BypassRowLevelSecurity = types.BoolValue(bypassRowLevelSecurity)
+	data.CanLogin = types.BoolValue(canLogin)
+	data.ConnectionLimit = types.Int32Value(connectionLimit)
+	data.CreateDatabase = types.BoolValue(createDatabase)
+	data.CreateRole = types.BoolValue(createRole)
+	data.Inherit = types.BoolValue(inherit)
+	data.Replication = types.BoolValue(replication)
+	data.Superuser = types.BoolValue(superuser)
+	data.ValidUntil = types.StringValue(validUntilString(validUntil, data.ValidUntil))
+
+	return nil
 }
 
 func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var dataFromPlan RoleResourceModel
+	var dataFromState RoleResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataFromPlan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &dataFromState)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -292,12 +522,58 @@ func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		}
 	}()
 
-	alterRoleSql := fmt.Sprintf("ALTER ROLE %s WITH %s;", dataFromPlan.Name.ValueString(), dataFromPlan.GetOptionsString(r))
+	renamed := !dataFromPlan.Name.Equal(dataFromState.Name)
+
+	if renamed {
+		renameRoleSql := fmt.Sprintf("ALTER ROLE %s RENAME TO %s;", pgsql.Identifier(dataFromState.Name.ValueString()), pgsql.Identifier(dataFromPlan.Name.ValueString()))
+
+		tflog.Info(ctx, renameRoleSql)
+
+		if _, err = txn.Exec(ctx, renameRoleSql); err != nil {
+			resp.Diagnostics.AddError("DB role update error", fmt.Sprintf("Error executing query '%s', got error: %s", renameRoleSql, err))
+			return
+		}
+	}
+
+	options := dataFromPlan.GetOptionsString(r)
+
+	// The password can't be read back from the server, so it's only sent when it changes in the configuration, or when
+	// the role is renamed, which clears MD5 passwords.
+	var passwordOption string
+	passwordChanged := !dataFromPlan.Password.Equal(dataFromState.Password) || !dataFromPlan.PasswordWoVersion.Equal(dataFromState.PasswordWoVersion)
+	if passwordChanged || renamed {
+		password, diags := getConfiguredPassword(ctx, req.Config, dataFromPlan)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			err = errors.New("unable to read the role's password")
+			return
+		}
+
+		if passwordChanged || !password.IsNull() {
+			passwordOption, err = r.getPasswordOptionString(ctx, txn, dataFromPlan.Name.ValueString(), password)
+			if err != nil {
+				resp.Diagnostics.AddError("DB role update error", fmt.Sprintf("Unable to hash the role's password, got error: %s", err))
+				return
+			}
+			options += " " + passwordOption
+			ctx = tflog.MaskMessageStrings(ctx, passwordOption)
+		}
+	}
+
+	alterRoleSql := fmt.Sprintf("ALTER ROLE %s WITH %s;", pgsql.Identifier(dataFromPlan.Name.ValueString()), options)
 
 	tflog.Info(ctx, alterRoleSql)
 
 	if _, err = txn.Exec(ctx, alterRoleSql); err != nil {
-		resp.Diagnostics.AddError("DB role update error", fmt.Sprintf("Error executing query '%s', got error: %s", alterRoleSql, err))
+		resp.Diagnostics.AddError("DB role update error", fmt.Sprintf("Error executing query '%s', got error: %s", redactPasswordOption(alterRoleSql, passwordOption), err))
+		return
+	}
+
+	resp.Diagnostics.Append(applyAllRoleParameters(ctx, txn, dataFromState, dataFromPlan)...)
+
+	if resp.Diagnostics.HasError() {
+		err = errors.New("unable to update the role's parameters")
 		return
 	}
 
@@ -310,6 +586,73 @@ func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromPlan)...)
+
+	resp.Diagnostics.Append(r.data.setServerObjectIdentity(ctx, resp.Identity, dataFromPlan.Oid)...)
+}
+
+func (r *RoleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.data.ConfigUnknown {
+		planUnknownValues(ctx, req, resp)
+		return
+	}
+
+	// Nothing to check when the role is being created or destroyed, or when the provider isn't configured yet.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || r.data.DbPool == nil {
+		return
+	}
+
+	var dataFromPlan RoleResourceModel
+	var dataFromState RoleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataFromPlan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &dataFromState)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.data.OnOIDMismatch == OnOIDMismatchReplace {
+		// Read keeps the OID of a role which was re-created outside of Terraform, which is replaced by planning a new OID.
+		var recreated bool
+		err := r.data.DbPool.QueryRow(ctx, "SELECT NOT EXISTS (SELECT FROM pg_roles WHERE oid = $1) AND EXISTS (SELECT FROM pg_roles WHERE rolname = $2);",
+			dataFromState.Oid.ValueInt64(), dataFromState.Name.ValueString()).Scan(&recreated)
+		if err != nil {
+			resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("Unable to check whether the role was re-created outside of Terraform, got error: %s", err))
+			return
+		}
+
+		if recreated {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("oid"), types.Int64Unknown())...)
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("oid"))
+			return
+		}
+	}
+
+	if !dataFromPlan.RenameInPlace.ValueBool() || !isKnown(dataFromPlan.Name) || dataFromPlan.Name.Equal(dataFromState.Name) {
+		return
+	}
+
+	// A configured password is set again after the rename.
+	password, diags := getConfiguredPassword(ctx, req.Config, dataFromPlan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || !password.IsNull() {
+		return
+	}
+
+	// pg_authid is only readable by superusers, so the warning is shown whenever the password can't be checked.
+	var isMd5Password bool
+	err := r.data.DbPool.QueryRow(ctx, "SELECT coalesce(rolpassword LIKE 'md5%', false) FROM pg_authid WHERE oid = $1;", dataFromState.Oid.ValueInt64()).Scan(&isMd5Password)
+	if err == nil && !isMd5Password {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("name"),
+		"Role password will be cleared",
+		fmt.Sprintf("Renaming role '%s' to '%s' clears its password if it's stored as an MD5 hash, as the role name is part of the hash. "+
+			"Set `password` or `password_wo` so that the password is set again after the rename.", dataFromState.Name.ValueString(), dataFromPlan.Name.ValueString()),
+	)
 }
 
 func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -322,6 +665,22 @@ func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	var dropBehavior RoleDropBehaviorModel
+	if !data.DropBehavior.IsNull() {
+		resp.Diagnostics.Append(data.DropBehavior.As(ctx, &dropBehavior, basetypes.ObjectAsOptions{})...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if statements := buildDropOwnedStatements(data.Name.ValueString(), dropBehavior); len(statements) > 0 {
+		if err := r.dropOwnedObjects(ctx, statements); err != nil {
+			resp.Diagnostics.AddError("DB role deletion error", fmt.Sprintf("Unable to handle the objects owned by role '%s', got error: %s", data.Name.ValueString(), err))
+			return
+		}
+	}
+
 	txn, err := r.data.DbPool.Begin(ctx)
 
 	if err != nil {
@@ -329,7 +688,7 @@ func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	dropRoleSql := fmt.Sprintf("DROP ROLE %s;", data.Name.ValueString())
+	dropRoleSql := fmt.Sprintf("DROP ROLE %s;", pgsql.Identifier(data.Name.ValueString()))
 
 	if _, err = txn.Exec(ctx, dropRoleSql); err != nil {
 		resp.Diagnostics.AddError("DB role deletion error", fmt.Sprintf("Error executing query '%s', got error: %s", dropRoleSql, err))
@@ -346,7 +705,209 @@ func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 }
 
 func (r *RoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	if r.data.ConfigUnknown {
+		resp.Diagnostics.AddError(
+			"Unknown provider configuration",
+			"Roles can't be imported while the provider configuration has values which are only known during apply.",
+		)
+		return
+	}
+
+	importID := req.ID
+
+	// Import blocks may identify the role with its identity instead of an import ID.
+	if importID == "" {
+		roleOID, diags := r.data.importedObjectOID(ctx, req.Identity)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		importID = fmt.Sprintf("oid:%d", roleOID)
+	}
+
+	query, arg, err := roleImportQuery(importID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	var roleOID uint32
+	var roleName string
+
+	err = r.data.DbPool.QueryRow(ctx, query, arg).Scan(&roleOID, &roleName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			resp.Diagnostics.AddError("Role not found", fmt.Sprintf("No Postgres role matching '%s' exists.", importID))
+		} else {
+			resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("Unable to look up the role to import, got error: %s", err))
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(r.seedImportedRole(ctx, resp.State.SetAttribute, roleOID, roleName)...)
+}
+
+// seedImportedRole sets the attributes of an imported role which Read doesn't refresh, with setAttribute: its OID and
+// name, and the attributes of the parameters the role has, as parameters are only read into attributes which are set.
+func (r *RoleResource) seedImportedRole(ctx context.Context, setAttribute func(context.Context, path.Path, any) diag.Diagnostics, roleOID uint32, roleName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(setAttribute(ctx, path.Root("oid"), int64(roleOID))...)
+	diags.Append(setAttribute(ctx, path.Root("name"), roleName)...)
+	diags.Append(setAttribute(ctx, path.Root("rename_in_place"), false)...)
+
+	parameters, err := r.readRoleParameters(ctx, int64(roleOID))
+	if err != nil {
+		diags.AddError("DB Query Error", fmt.Sprintf("Unable to read the role's parameters, got error: %s", err))
+		return diags
+	}
+
+	databaseParameters := map[string]map[string]string{}
+	for database := range parameters {
+		if database != "" {
+			databaseParameters[database] = map[string]string{}
+		}
+	}
+
+	if _, ok := parameters[""]; ok {
+		diags.Append(setAttribute(ctx, path.Root("parameters"), map[string]string{})...)
+	}
+	if len(databaseParameters) > 0 {
+		diags.Append(setAttribute(ctx, path.Root("database_parameters"), databaseParameters)...)
+	}
+
+	return diags
+}
+
+// roleImportQuery returns the query looking up the OID and name of the role identified by importID, either the role's
+// name or `oid:` followed by its OID, along with the query's argument.
+func roleImportQuery(importID string) (string, any, error) {
+	oidString, ok := strings.CutPrefix(importID, "oid:")
+	if !ok {
+		return "SELECT oid, rolname FROM pg_roles WHERE rolname = $1", importID, nil
+	}
+
+	roleOID, err := strconv.ParseUint(oidString, 10, 32)
+	if err != nil || roleOID == 0 {
+		return "", nil, fmt.Errorf("expected a role name, or oid: followed by the OID of the role such as oid:16384, got: %s", importID)
+	}
+
+	return "SELECT oid, rolname FROM pg_roles WHERE oid = $1", uint32(roleOID), nil
+}
+
+// dropOwnedObjects runs the given statements in every database which allows connections, each database within a
+// single transaction. REASSIGN OWNED and DROP OWNED only affect the current database, apart from shared objects such as
+// databases and tablespaces.
+func (r *RoleResource) dropOwnedObjects(ctx context.Context, statements []string) error {
+	rows, err := r.data.DbPool.Query(ctx, "SELECT datname FROM pg_database WHERE datallowconn ORDER BY datname;")
+	if err != nil {
+		return fmt.Errorf("unable to list databases: %w", err)
+	}
+
+	databaseNames, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("unable to list databases: %w", err)
+	}
+
+	for _, databaseName := range databaseNames {
+		if err := r.execInDatabase(ctx, databaseName, statements); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *RoleResource) execInDatabase(ctx context.Context, databaseName string, statements []string) error {
+	pool, err := r.data.DatabasePool(ctx, databaseName)
+	if err != nil {
+		return fmt.Errorf("unable to connect to database '%s': %w", databaseName, err)
+	}
+
+	return pgx.BeginFunc(ctx, pool, func(txn pgx.Tx) error {
+		for _, statement := range statements {
+			tflog.Info(ctx, statement, map[string]any{"database": databaseName})
+
+			if _, err := txn.Exec(ctx, statement); err != nil {
+				return fmt.Errorf("error executing query '%s' in database '%s': %w", statement, databaseName, err)
+			}
+		}
+		return nil
+	})
+}
+
+// buildDropOwnedStatements returns the statements to run in each database before the role can be dropped.
+func buildDropOwnedStatements(roleName string, dropBehavior RoleDropBehaviorModel) []string {
+	var statements []string
+
+	if isKnown(dropBehavior.ReassignOwnedTo) {
+		statements = append(statements, fmt.Sprintf("REASSIGN OWNED BY %s TO %s;", pgsql.Identifier(roleName), pgsql.Identifier(dropBehavior.ReassignOwnedTo.ValueString())))
+	}
+	if dropBehavior.DropOwned.ValueBool() {
+		statements = append(statements, fmt.Sprintf("DROP OWNED BY %s;", pgsql.Identifier(roleName)))
+	}
+
+	return statements
+}
+
+// renameRequiresReplace requires the role to be replaced when its name changes, unless `rename_in_place` is enabled.
+func renameRequiresReplace(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	var renameInPlace types.Bool
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rename_in_place"), &renameInPlace)...)
+
+	resp.RequiresReplace = !renameInPlace.ValueBool()
+}
+
+// getPasswordOptionString returns the PASSWORD option of CREATE ROLE and ALTER ROLE for the given password. Unless it's
+// already hashed, the password is hashed according to the server's password_encryption setting so that the plaintext
+// password never reaches the server, where it could end up in the server logs. A null password removes the password.
+func (r *RoleResource) getPasswordOptionString(ctx context.Context, txn pgx.Tx, roleName string, password types.String) (string, error) {
+	if password.IsNull() {
+		return "PASSWORD NULL", nil
+	}
+
+	encryptedPassword := password.ValueString()
+
+	if !postgresql.IsEncryptedPassword(encryptedPassword) {
+		var passwordEncryption string
+		if err := txn.QueryRow(ctx, "SELECT current_setting('password_encryption');").Scan(&passwordEncryption); err != nil {
+			return "", fmt.Errorf("unable to read the password_encryption setting: %w", err)
+		}
+
+		var err error
+		encryptedPassword, err = postgresql.EncryptPassword(encryptedPassword, roleName, passwordEncryption)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return fmt.Sprintf("PASSWORD %s", pgsql.Literal(encryptedPassword)), nil
+}
+
+// getConfiguredPassword returns the password of the role, taken from the write-only `password_wo` attribute when it's
+// set, which is only available from the configuration.
+func getConfiguredPassword(ctx context.Context, config tfsdk.Config, dataFromPlan RoleResourceModel) (types.String, diag.Diagnostics) {
+	var passwordWo types.String
+
+	diags := config.GetAttribute(ctx, path.Root("password_wo"), &passwordWo)
+
+	if !passwordWo.IsNull() {
+		return passwordWo, diags
+	}
+
+	return dataFromPlan.Password, diags
+}
+
+// redactPasswordOption removes the PASSWORD option from a statement before it's included in an error message.
+func redactPasswordOption(statement string, passwordOption string) string {
+	if passwordOption == "" {
+		return statement
+	}
+
+	return strings.ReplaceAll(statement, passwordOption, "PASSWORD ***")
 }
 
 func (r *RoleResourceModel) BypassRowLevelSecurityAsOptionString() string {
@@ -369,6 +930,14 @@ func (r *RoleResourceModel) ConnectionLimitAsOptionString() string {
 	return fmt.Sprintf("CONNECTION LIMIT %d", r.ConnectionLimit.ValueInt32())
 }
 
+func (r *RoleResourceModel) CreateDatabaseAsOptionString() string {
+	if r.CreateDatabase.ValueBool() {
+		return "CREATEDB"
+	} else {
+		return "NOCREATEDB"
+	}
+}
+
 func (r *RoleResourceModel) CreateRoleAsOptionString() string {
 	if r.CreateRole.ValueBool() {
 		return "CREATEROLE"
@@ -401,16 +970,71 @@ func (r *RoleResourceModel) SuperuserAsOptionString() string {
 	}
 }
 
+func (r *RoleResourceModel) ValidUntilAsOptionString() string {
+	return fmt.Sprintf("VALID UNTIL %s", pgsql.Literal(r.ValidUntil.ValueString()))
+}
+
 func (r *RoleResourceModel) GetOptionsString(resource *RoleResource) string {
 	options := []string{
 		r.BypassRowLevelSecurityAsOptionString(),
 		r.CanLoginAsOptionString(),
 		r.ConnectionLimitAsOptionString(),
+		r.CreateDatabaseAsOptionString(),
 		r.CreateRoleAsOptionString(),
 		r.InheritAsOptionString(),
 		r.ReplicationAsOptionString(),
 		r.SuperuserAsOptionString(),
+		r.ValidUntilAsOptionString(),
 	}
 
 	return strings.Join(options, " ")
 }
+
+// validUntilString returns the string form of a role's rolvaliduntil. When the prior value denotes the same point in
+// time, e.g. in another time zone, the prior value is kept so that plans stay stable.
+func validUntilString(validUntil pgtype.Timestamptz, priorValue types.String) string {
+	// A role without an expiry date has a NULL rolvaliduntil.
+	if !validUntil.Valid || validUntil.InfinityModifier == pgtype.Infinity {
+		return "infinity"
+	}
+	if validUntil.InfinityModifier == pgtype.NegativeInfinity {
+		return "-infinity"
+	}
+
+	if priorTime, err := time.Parse(time.RFC3339, priorValue.ValueString()); err == nil && priorTime.Equal(validUntil.Time) {
+		return priorValue.ValueString()
+	}
+
+	return validUntil.Time.UTC().Format(time.RFC3339)
+}
+
+// validUntilValidator validates that a string is either an RFC 3339 timestamp, or one of the special `infinity` and
+// `-infinity` values.
+type validUntilValidator struct{}
+
+func (v validUntilValidator) Description(ctx context.Context) string {
+	return "value must be an RFC 3339 timestamp, or infinity"
+}
+
+func (v validUntilValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be an RFC 3339 timestamp, or `infinity`"
+}
+
+func (v validUntilValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if !isKnown(req.ConfigValue) {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if value == "infinity" || value == "-infinity" {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid timestamp",
+			fmt.Sprintf("Expected an RFC 3339 timestamp such as 2030-01-01T00:00:00Z, or infinity, got: %s", value),
+		)
+	}
+}
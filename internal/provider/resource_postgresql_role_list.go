@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackc/pgx/v5"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &RoleListResource{}
+var _ list.ListResourceWithConfigure = &RoleListResource{}
+
+func NewRoleListResource() list.ListResource {
+	return &RoleListResource{}
+}
+
+// RoleListResource lists the roles of the server, e.g. to import the roles which aren't managed by Terraform yet.
+type RoleListResource struct {
+	role RoleResource
+}
+
+type RoleListResourceModel struct {
+	NamePattern        types.String `tfsdk:"name_pattern"`
+	CanLogin           types.Bool   `tfsdk:"can_login"`
+	IncludeSystemRoles types.Bool   `tfsdk:"include_system_roles"`
+}
+
+func (r *RoleListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	r.role.Metadata(ctx, req, resp)
+}
+
+func (r *RoleListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the Postgresql roles of the server.",
+
+		Attributes: map[string]schema.Attribute{
+			"name_pattern": schema.StringAttribute{
+				Description: "A `LIKE` pattern the names of the listed roles match, such as `app\\_%`.",
+				Optional:    true,
+			},
+			"can_login": schema.BoolAttribute{
+				Description: "Lists only the roles which can log in when true, or only the roles which can't when false.",
+				Optional:    true,
+			},
+			"include_system_roles": schema.BoolAttribute{
+				Description: "Determines whether the predefined roles, whose names start with `pg_`, are listed. Defaults to false.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *RoleListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.role.Configure(ctx, req, resp)
+}
+
+func (r *RoleListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var diags diag.Diagnostics
+
+	if r.role.data.ConfigUnknown {
+		diags.AddError(
+			"Unknown provider configuration",
+			"Roles can't be listed while the provider configuration has values which are only known during apply.",
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var filters RoleListResourceModel
+
+	diags.Append(req.Config.Get(ctx, &filters)...)
+
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	// The identities lack the system identifier, along with a warning, when it can't be determined.
+	identity, identityDiags := r.role.data.serverObjectIdentity(ctx, types.Int64Null())
+
+	query, args := buildRoleListQuery(filters, req.Limit)
+
+	roles, err := queryListedObjects(ctx, r.role.data.DbPool, query, args)
+	if err != nil {
+		diags.AddError("DB Query Error", fmt.Sprintf("Unable to list the roles, got error: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		if len(identityDiags) > 0 && !push(list.ListResult{Diagnostics: identityDiags}) {
+			return
+		}
+
+		for _, role := range roles {
+			result := req.NewListResult(ctx)
+			result.DisplayName = role.name
+
+			identity.Oid = types.Int64Value(int64(role.oid))
+			result.Diagnostics.Append(result.Identity.Set(ctx, identity)...)
+
+			if req.IncludeResource {
+				result.Diagnostics.Append(r.role.readListedRole(ctx, result.Resource, role)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// readListedRole sets resource to the state of a listed role, as if it was imported.
+func (r *RoleResource) readListedRole(ctx context.Context, resource *tfsdk.Resource, role listedObject) diag.Diagnostics {
+	diags := r.seedImportedRole(ctx, resource.SetAttribute, role.oid, role.name)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	var data RoleResourceModel
+	diags.Append(resource.Get(ctx, &data)...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := r.readRole(ctx, &data); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			diags.AddError("Role not found", fmt.Sprintf("Role '%s' was dropped while it was listed.", role.name))
+		} else {
+			diags.AddError("DB Query Error", fmt.Sprintf("Unable to read role '%s', got error: %s", role.name, err))
+		}
+		return diags
+	}
+
+	diags.Append(r.readParameters(ctx, &data)...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(resource.Set(ctx, &data)...)
+
+	return diags
+}
+
+// buildRoleListQuery returns the query listing the OIDs and names of the roles matching filters, along with the
+// query's arguments.
+func buildRoleListQuery(filters RoleListResourceModel, limit int64) (string, []any) {
+	var query listQuery
+
+	if !filters.NamePattern.IsNull() {
+		query.whereArg("rolname LIKE", filters.NamePattern.ValueString())
+	}
+	if !filters.CanLogin.IsNull() {
+		query.whereArg("rolcanlogin =", filters.CanLogin.ValueBool())
+	}
+	if !filters.IncludeSystemRoles.ValueBool() {
+		query.where(`rolname NOT LIKE 'pg\_%'`)
+	}
+
+	return query.build("SELECT oid, rolname FROM pg_roles", "rolname", limit)
+}
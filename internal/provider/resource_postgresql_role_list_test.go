@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck/queryfilter"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccRoleListResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// List resources were introduced in Terraform 1.14
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig() + `
+resource "postgresql_role" "login" {
+  name      = "list_login_role"
+  can_login = true
+}
+
+resource "postgresql_role" "group" {
+  name = "list_group_role"
+}
+`,
+			},
+			// Test listing the roles matching a name pattern
+			{
+				Query: true,
+				Config: providerConfig() + `
+list "postgresql_role" "test" {
+  provider         = postgresql
+  include_resource = true
+
+  config {
+    name_pattern = "list\\_%"
+  }
+}
+`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("postgresql_role.test", 2),
+					querycheck.ExpectIdentity("postgresql_role.test", map[string]knownvalue.Check{
+						"system_identifier": knownvalue.StringRegexp(regexp.MustCompile(`^-?[0-9]+$`)),
+						"oid":               knownvalue.NotNull(),
+					}),
+					querycheck.ExpectResourceDisplayName("postgresql_role.test", queryfilter.ByDisplayName(knownvalue.StringExact("list_login_role")), knownvalue.StringExact("list_login_role")),
+					querycheck.ExpectResourceKnownValues("postgresql_role.test", queryfilter.ByDisplayName(knownvalue.StringExact("list_login_role")), []querycheck.KnownValueCheck{
+						{Path: tfjsonpath.New("can_login"), KnownValue: knownvalue.Bool(true)},
+						{Path: tfjsonpath.New("rename_in_place"), KnownValue: knownvalue.Bool(false)},
+					}),
+				},
+			},
+			// Test listing the roles which can't log in
+			{
+				Query: true,
+				Config: providerConfig() + `
+list "postgresql_role" "test" {
+  provider = postgresql
+
+  config {
+    name_pattern = "list\\_%"
+    can_login    = false
+  }
+}
+`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("postgresql_role.test", 1),
+					querycheck.ExpectResourceDisplayName("postgresql_role.test", queryfilter.ByDisplayName(knownvalue.StringExact("list_group_role")), knownvalue.StringExact("list_group_role")),
+				},
+			},
+			// Test listing the predefined roles
+			{
+				Query: true,
+				Config: providerConfig() + `
+list "postgresql_role" "test" {
+  provider = postgresql
+
+  config {
+    name_pattern         = "pg\\_monitor"
+    include_system_roles = true
+  }
+}
+`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("postgresql_role.test", 1),
+				},
+			},
+		},
+	})
+}
+
+func TestBuildRoleListQuery(t *testing.T) {
+	testCases := []struct {
+		testName      string
+		filters       RoleListResourceModel
+		limit         int64
+		expectedQuery string
+		expectedArgs  []any
+	}{
+		{
+			testName: "No filters",
+			filters: RoleListResourceModel{
+				NamePattern:        types.StringNull(),
+				CanLogin:           types.BoolNull(),
+				IncludeSystemRoles: types.BoolNull(),
+			},
+			expectedQuery: `SELECT oid, rolname FROM pg_roles WHERE rolname NOT LIKE 'pg\_%' ORDER BY rolname;`,
+			expectedArgs:  []any{},
+		},
+		{
+			testName: "System roles",
+			filters: RoleListResourceModel{
+				NamePattern:        types.StringNull(),
+				CanLogin:           types.BoolNull(),
+				IncludeSystemRoles: types.BoolValue(true),
+			},
+			expectedQuery: `SELECT oid, rolname FROM pg_roles ORDER BY rolname;`,
+			expectedArgs:  []any{},
+		},
+		{
+			testName: "Every filter",
+			filters: RoleListResourceModel{
+				NamePattern:        types.StringValue("app\\_%"),
+				CanLogin:           types.BoolValue(false),
+				IncludeSystemRoles: types.BoolValue(false),
+			},
+			expectedQuery: `SELECT oid, rolname FROM pg_roles WHERE rolname LIKE $1 AND rolcanlogin = $2 AND rolname NOT LIKE 'pg\_%' ORDER BY rolname;`,
+			expectedArgs:  []any{"app\\_%", false},
+		},
+		{
+			testName: "Limit",
+			filters: RoleListResourceModel{
+				NamePattern:        types.StringValue("app\\_%"),
+				CanLogin:           types.BoolNull(),
+				IncludeSystemRoles: types.BoolValue(true),
+			},
+			limit:         10,
+			expectedQuery: `SELECT oid, rolname FROM pg_roles WHERE rolname LIKE $1 ORDER BY rolname LIMIT $2;`,
+			expectedArgs:  []any{"app\\_%", int64(10)},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			query, args := buildRoleListQuery(testCase.filters, testCase.limit)
+			assert.Equal(t, testCase.expectedQuery, query)
+			assert.Equal(t, testCase.expectedArgs, args)
+		})
+	}
+}
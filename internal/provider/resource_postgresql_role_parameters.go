@@ -0,0 +1,315 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx/v5"
+	"github.com/ktham/terraform-provider-postgresql/internal/postgresql"
+	"github.com/ktham/terraform-provider-postgresql/internal/postgresql/pgsql"
+)
+
+// settingDefinition holds the columns of pg_settings needed to compare values of a configuration parameter.
+type settingDefinition struct {
+	vartype string
+	unit    string
+}
+
+// applyRoleParameters sets and resets the configuration parameters of a role so that they go from the prior to the
+// planned parameters, for all databases when database is empty and otherwise only in the given database.
+func applyRoleParameters(ctx context.Context, txn pgx.Tx, roleName string, database string, prior map[string]string, planned map[string]string) error {
+	for _, statement := range buildRoleParameterStatements(roleName, database, prior, planned) {
+		tflog.Info(ctx, statement)
+
+		if _, err := txn.Exec(ctx, statement); err != nil {
+			return fmt.Errorf("error executing query '%s', got error: %w", statement, err)
+		}
+	}
+
+	return nil
+}
+
+// applyAllRoleParameters applies the changes of both the `parameters` and `database_parameters` attributes.
+func applyAllRoleParameters(ctx context.Context, txn pgx.Tx, prior RoleResourceModel, planned RoleResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Null maps, including the zero values of a model which was never read, have no parameters. They're skipped, as the
+	// zero values have no element type to convert their elements with.
+	priorParameters, plannedParameters := map[string]string{}, map[string]string{}
+	if !prior.Parameters.IsNull() {
+		diags.Append(prior.Parameters.ElementsAs(ctx, &priorParameters, true)...)
+	}
+	if !planned.Parameters.IsNull() {
+		diags.Append(planned.Parameters.ElementsAs(ctx, &plannedParameters, true)...)
+	}
+
+	priorDatabaseParameters, plannedDatabaseParameters := map[string]map[string]string{}, map[string]map[string]string{}
+	if !prior.DatabaseParameters.IsNull() {
+		diags.Append(prior.DatabaseParameters.ElementsAs(ctx, &priorDatabaseParameters, true)...)
+	}
+	if !planned.DatabaseParameters.IsNull() {
+		diags.Append(planned.DatabaseParameters.ElementsAs(ctx, &plannedDatabaseParameters, true)...)
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	roleName := planned.Name.ValueString()
+
+	if err := applyRoleParameters(ctx, txn, roleName, "", priorParameters, plannedParameters); err != nil {
+		diags.AddError("DB role parameters error", err.Error())
+		return diags
+	}
+
+	databases := make([]string, 0, len(priorDatabaseParameters)+len(plannedDatabaseParameters))
+	for database := range priorDatabaseParameters {
+		databases = append(databases, database)
+	}
+	for database := range plannedDatabaseParameters {
+		if _, ok := priorDatabaseParameters[database]; !ok {
+			databases = append(databases, database)
+		}
+	}
+	slices.Sort(databases)
+
+	for _, database := range databases {
+		if err := applyRoleParameters(ctx, txn, roleName, database, priorDatabaseParameters[database], plannedDatabaseParameters[database]); err != nil {
+			diags.AddError("DB role parameters error", err.Error())
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// buildRoleParameterStatements returns the ALTER ROLE statements that reset the parameters which are no longer
+// planned, and set the parameters which are new or have a new value.
+func buildRoleParameterStatements(roleName string, database string, prior map[string]string, planned map[string]string) []string {
+	var statements []string
+
+	for _, name := range sortedKeys(prior) {
+		if _, ok := planned[name]; !ok {
+			statements = append(statements, fmt.Sprintf("%s RESET %s;", alterRoleInDatabaseClause(roleName, database), parameterName(name)))
+		}
+	}
+
+	for _, name := range sortedKeys(planned) {
+		if priorValue, ok := prior[name]; !ok || priorValue != planned[name] {
+			statements = append(statements, fmt.Sprintf(
+				"%s SET %s = %s;", alterRoleInDatabaseClause(roleName, database), parameterName(name), parameterValue(name, planned[name]),
+			))
+		}
+	}
+
+	return statements
+}
+
+func alterRoleInDatabaseClause(roleName string, database string) string {
+	if database == "" {
+		return fmt.Sprintf("ALTER ROLE %s", pgsql.Identifier(roleName))
+	}
+
+	return fmt.Sprintf("ALTER ROLE %s IN DATABASE %s", pgsql.Identifier(roleName), pgsql.Identifier(database))
+}
+
+// parameterName quotes the name of a configuration parameter, which can be qualified, e.g. `app.tenant`, for
+// parameters defined by extensions or applications.
+func parameterName(name string) string {
+	return pgsql.QualifiedIdentifier(strings.Split(name, ".")...)
+}
+
+// parameterValue quotes the value of a configuration parameter. The elements of list parameters such as search_path
+// are quoted separately, as the server would otherwise take the whole value as a single element.
+func parameterValue(name string, value string) string {
+	if !postgresql.IsListSetting(name) {
+		return pgsql.Literal(value)
+	}
+
+	elements := postgresql.SplitListSetting(value)
+	if len(elements) == 0 {
+		return "''"
+	}
+
+	quotedElements := make([]string, len(elements))
+	for i, element := range elements {
+		quotedElements[i] = pgsql.Literal(element)
+	}
+
+	return strings.Join(quotedElements, ", ")
+}
+
+// readRoleParameters returns the configuration parameters of a role from pg_db_role_setting, keyed by database name.
+// The parameters which apply to all databases are keyed by the empty string.
+func (r *RoleResource) readRoleParameters(ctx context.Context, roleOid int64) (map[string]map[string]string, error) {
+	query := `
+SELECT
+    coalesce(d.datname, ''),
+    s.setconfig
+FROM
+    pg_db_role_setting s
+    LEFT JOIN pg_database d ON d.oid = s.setdatabase
+WHERE
+    s.setrole = $1;`
+
+	rows, err := r.data.DbPool.Query(ctx, query, roleOid)
+	if err != nil {
+		return nil, fmt.Errorf("error executing query '%s', got error: %w", query, err)
+	}
+
+	parameters := map[string]map[string]string{}
+
+	var database string
+	var config []string
+	_, err = pgx.ForEachRow(rows, []any{&database, &config}, func() error {
+		parameters[database] = parseRoleConfig(config)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error executing query '%s', got error: %w", query, err)
+	}
+
+	return parameters, nil
+}
+
+// parseRoleConfig parses the `name=value` entries of pg_db_role_setting.setconfig.
+func parseRoleConfig(config []string) map[string]string {
+	parameters := make(map[string]string, len(config))
+
+	for _, entry := range config {
+		if name, value, ok := strings.Cut(entry, "="); ok {
+			parameters[name] = value
+		}
+	}
+
+	return parameters
+}
+
+// readSettingDefinitions returns the type and unit of the given configuration parameters, keyed by their lowercased
+// name. Parameters that the server doesn't know about, such as application-defined parameters, are omitted.
+func (r *RoleResource) readSettingDefinitions(ctx context.Context, names []string) (map[string]settingDefinition, error) {
+	definitions := map[string]settingDefinition{}
+	if len(names) == 0 {
+		return definitions, nil
+	}
+
+	query := "SELECT lower(name), vartype, coalesce(unit, '') FROM pg_settings WHERE lower(name) = ANY($1);"
+
+	rows, err := r.data.DbPool.Query(ctx, query, names)
+	if err != nil {
+		return nil, fmt.Errorf("error executing query '%s', got error: %w", query, err)
+	}
+
+	var name string
+	var definition settingDefinition
+	_, err = pgx.ForEachRow(rows, []any{&name, &definition.vartype, &definition.unit}, func() error {
+		definitions[name] = definition
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error executing query '%s', got error: %w", query, err)
+	}
+
+	return definitions, nil
+}
+
+// reconcileRoleParameters returns the actual parameters of a role, keeping the prior name and value of parameters
+// whose actual value is equivalent, e.g. `1min` and `60s`, so that plans stay stable.
+func reconcileRoleParameters(prior map[string]string, actual map[string]string, definitions map[string]settingDefinition) map[string]string {
+	reconciled := make(map[string]string, len(actual))
+
+	for actualName, actualValue := range actual {
+		name, value := actualName, actualValue
+
+		for priorName, priorValue := range prior {
+			if !strings.EqualFold(priorName, actualName) {
+				continue
+			}
+
+			name = priorName
+			definition := definitions[strings.ToLower(actualName)]
+			if postgresql.SettingValuesEqual(actualName, priorValue, actualValue, definition.vartype, definition.unit) {
+				value = priorValue
+			}
+		}
+
+		reconciled[name] = value
+	}
+
+	return reconciled
+}
+
+// readParameters refreshes the `parameters` and `database_parameters` attributes of the model. Each attribute is only
+// managed once it's set, and `database_parameters` only manages the databases it lists.
+func (r *RoleResource) readParameters(ctx context.Context, data *RoleResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if data.Parameters.IsNull() && data.DatabaseParameters.IsNull() {
+		return diags
+	}
+
+	actualParameters, err := r.readRoleParameters(ctx, data.Oid.ValueInt64())
+	if err != nil {
+		diags.AddError("DB Query Error", fmt.Sprintf("Unable to read the role's parameters, got error: %s", err))
+		return diags
+	}
+
+	priorParameters := map[string]string{}
+	diags.Append(data.Parameters.ElementsAs(ctx, &priorParameters, true)...)
+
+	priorDatabaseParameters := map[string]map[string]string{}
+	diags.Append(data.DatabaseParameters.ElementsAs(ctx, &priorDatabaseParameters, true)...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	var names []string
+	for _, parameters := range actualParameters {
+		for name := range parameters {
+			names = append(names, strings.ToLower(name))
+		}
+	}
+
+	definitions, err := r.readSettingDefinitions(ctx, names)
+	if err != nil {
+		diags.AddError("DB Query Error", fmt.Sprintf("Unable to read the definitions of the role's parameters, got error: %s", err))
+		return diags
+	}
+
+	if !data.Parameters.IsNull() {
+		parameters := reconcileRoleParameters(priorParameters, actualParameters[""], definitions)
+
+		var d diag.Diagnostics
+		data.Parameters, d = types.MapValueFrom(ctx, types.StringType, parameters)
+		diags.Append(d...)
+	}
+
+	if !data.DatabaseParameters.IsNull() {
+		databaseParameters := make(map[string]map[string]string, len(priorDatabaseParameters))
+		for database, prior := range priorDatabaseParameters {
+			databaseParameters[database] = reconcileRoleParameters(prior, actualParameters[database], definitions)
+		}
+
+		var d diag.Diagnostics
+		data.DatabaseParameters, d = types.MapValueFrom(ctx, types.MapType{ElemType: types.StringType}, databaseParameters)
+		diags.Append(d...)
+	}
+
+	return diags
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
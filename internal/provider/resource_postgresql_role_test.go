@@ -1,10 +1,31 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccRoleResource(t *testing.T) {
@@ -19,6 +40,8 @@ func TestAccRoleResource(t *testing.T) {
 					resource.TestCheckResourceAttr("postgresql_role.test", "name", "role1"),
 					resource.TestCheckResourceAttr("postgresql_role.test", "can_login", "true"),
 					resource.TestCheckResourceAttr("postgresql_role.test", "connection_limit", "10"),
+					resource.TestCheckResourceAttr("postgresql_role.test", "create_database", "true"),
+					resource.TestCheckResourceAttr("postgresql_role.test", "valid_until", "2035-06-01T12:00:00+02:00"),
 				),
 			},
 			// Test role update
@@ -39,10 +62,191 @@ func TestAccRoleResource(t *testing.T) {
 					resource.TestCheckResourceAttr("postgresql_role.test", "connection_limit", "15"),
 				),
 			},
+			// Test role import by name
+			{
+				ResourceName:                         "postgresql_role.test",
+				ImportState:                          true,
+				ImportStateId:                        "role2",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+				// An imported valid_until is in UTC, as there's no prior value to keep the time zone of.
+				ImportStateVerifyIgnore: []string{"valid_until"},
+				ImportStateCheck:        testAccRoleImportedValidUntilCheck("2035-06-01T12:00:00+02:00"),
+			},
+			// Test role import by OID
+			{
+				ResourceName:                         "postgresql_role.test",
+				ImportState:                          true,
+				ImportStateIdFunc:                    testAccRoleImportStateIdFunc("postgresql_role.test"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+				// An imported valid_until is in UTC, as there's no prior value to keep the time zone of.
+				ImportStateVerifyIgnore: []string{"valid_until"},
+				ImportStateCheck:        testAccRoleImportedValidUntilCheck("2035-06-01T12:00:00+02:00"),
+			},
+			// Test role import of a role which doesn't exist
+			{
+				ResourceName:  "postgresql_role.test",
+				ImportState:   true,
+				ImportStateId: "missing_role",
+				ExpectError:   regexp.MustCompile(`No Postgres role matching 'missing_role' exists`),
+			},
 		},
 	})
 }
 
+// testAccRoleImportStateIdFunc returns the `oid:` import ID of the role of resourceName.
+func testAccRoleImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(state *terraform.State) (string, error) {
+		roleResource, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		return "oid:" + roleResource.Primary.Attributes["oid"], nil
+	}
+}
+
+// testAccRoleImportedValidUntilCheck returns an import state check that the valid_until of the imported role is the
+// same point in time as validUntil.
+func testAccRoleImportedValidUntilCheck(validUntil string) resource.ImportStateCheckFunc {
+	return func(states []*terraform.InstanceState) error {
+		if len(states) != 1 {
+			return fmt.Errorf("expected 1 imported role, got: %d", len(states))
+		}
+
+		expected, err := time.Parse(time.RFC3339, validUntil)
+		if err != nil {
+			return err
+		}
+
+		imported, err := time.Parse(time.RFC3339, states[0].Attributes["valid_until"])
+		if err != nil {
+			return fmt.Errorf("unable to parse the imported valid_until, got error: %w", err)
+		}
+
+		if !imported.Equal(expected) {
+			return fmt.Errorf("expected the imported valid_until to be %s, got: %s", validUntil, states[0].Attributes["valid_until"])
+		}
+
+		return nil
+	}
+}
+
+func TestAccRoleResourceIdentity(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Resource identity was introduced in Terraform 1.12
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig() + testAccRoleResourceIdentityConfig("identity_role"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("postgresql_role.test", map[string]knownvalue.Check{
+						"system_identifier": knownvalue.StringRegexp(regexp.MustCompile(`^-?[0-9]+$`)),
+						"oid":               knownvalue.NotNull(),
+					}),
+					statecheck.ExpectIdentityValueMatchesState("postgresql_role.test", tfjsonpath.New("oid")),
+				},
+			},
+			// Test role import with an import block identifying the role with its identity
+			{
+				ResourceName:    "postgresql_role.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+			// Test role import of a role of another server
+			{
+				Config: providerConfig() + testAccRoleResourceIdentityConfig("identity_role") + `
+import {
+  to = postgresql_role.other
+  identity = {
+    system_identifier = "1"
+    oid               = 16384
+  }
+}
+
+resource "postgresql_role" "other" {
+  name = "other_role"
+}
+`,
+				ExpectError: regexp.MustCompile(`belongs to the server with system identifier 1,`),
+			},
+		},
+	})
+}
+
+func testAccRoleResourceIdentityConfig(name string) string {
+	return fmt.Sprintf(`
+resource "postgresql_role" "test" {
+  name = %q
+}
+`, name)
+}
+
+func TestRoleImportQuery(t *testing.T) {
+	testCases := []struct {
+		testName      string
+		importID      string
+		expectedQuery string
+		expectedArg   any
+		expectedErr   bool
+	}{
+		{
+			testName:      "Name",
+			importID:      "app_user",
+			expectedQuery: "SELECT oid, rolname FROM pg_roles WHERE rolname = $1",
+			expectedArg:   "app_user",
+		},
+		{
+			testName:      "Name with a colon",
+			importID:      "team:app_user",
+			expectedQuery: "SELECT oid, rolname FROM pg_roles WHERE rolname = $1",
+			expectedArg:   "team:app_user",
+		},
+		{
+			testName:      "OID",
+			importID:      "oid:16384",
+			expectedQuery: "SELECT oid, rolname FROM pg_roles WHERE oid = $1",
+			expectedArg:   uint32(16384),
+		},
+		{
+			testName:    "Invalid OID",
+			importID:    "oid:app_user",
+			expectedErr: true,
+		},
+		{
+			testName:    "Out of range OID",
+			importID:    "oid:4294967296",
+			expectedErr: true,
+		},
+		{
+			testName:    "Zero OID",
+			importID:    "oid:0",
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			query, arg, err := roleImportQuery(testCase.importID)
+			if testCase.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedQuery, query)
+			assert.Equal(t, testCase.expectedArg, arg)
+		})
+	}
+}
+
 func testAccRoleResourceConfig(name string, canLogin bool, connectionLimit int32) string {
 	return fmt.Sprintf(`
 resource "postgresql_role" "test" {
@@ -50,10 +254,643 @@ resource "postgresql_role" "test" {
   bypass_row_level_security = true
   can_login                 = %t
   connection_limit          = %d
+  create_database           = true
   create_role               = true
   inherit                   = true
   replication               = true
   superuser                 = true
+  valid_until               = "2035-06-01T12:00:00+02:00"
 }
 `, name, canLogin, connectionLimit)
 }
+
+func TestAccRoleResourceQuotedName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test role names which have to be quoted
+			{
+				Config: providerConfig() + testAccRoleResourceConfig(`App-User "é"; DROP ROLE terraform; --`, true, 10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role.test", "name", `App-User "é"; DROP ROLE terraform; --`),
+					resource.TestCheckResourceAttrSet("postgresql_role.test", "oid"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRoleResourceRenameInPlace(t *testing.T) {
+	sameOid := statecheck.CompareValue(compare.ValuesSame())
+
+	// The password is stored as an MD5 hash, which is salted with the role name and so is cleared by a rename.
+	storeMd5Password := func(name string) resource.TestCheckFunc {
+		return testAccExec(fmt.Sprintf("SET password_encryption = 'md5'; ALTER ROLE %s PASSWORD 'rename_password';", name))
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test role creation
+			{
+				Config: providerConfig() + testAccRoleResourceRenameInPlaceConfig("rename_role1", true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					sameOid.AddStateValue("postgresql_role.test", tfjsonpath.New("oid")),
+				},
+				Check: storeMd5Password("rename_role1"),
+			},
+			// Test the role is renamed rather than replaced, and its password is set again
+			{
+				Config: providerConfig() + testAccRoleResourceRenameInPlaceConfig("rename_role2", true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("postgresql_role.test", plancheck.ResourceActionUpdate),
+						expectRoleRenamePasswordWarning{resourceAddress: "postgresql_role.test", expectWarning: false},
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					sameOid.AddStateValue("postgresql_role.test", tfjsonpath.New("oid")),
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role.test", "name", "rename_role2"),
+					testAccCheckRoleLogin("rename_role2", "rename_password"),
+					storeMd5Password("rename_role2"),
+				),
+			},
+			// Test renaming a role without a configured password warns that its MD5-hashed password is cleared
+			{
+				Config: providerConfig() + testAccRoleResourceRenameInPlaceConfig("rename_role3", false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("postgresql_role.test", plancheck.ResourceActionUpdate),
+						expectRoleRenamePasswordWarning{resourceAddress: "postgresql_role.test", expectWarning: true},
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					sameOid.AddStateValue("postgresql_role.test", tfjsonpath.New("oid")),
+				},
+				Check: resource.TestCheckResourceAttr("postgresql_role.test", "name", "rename_role3"),
+			},
+		},
+	})
+}
+
+func testAccRoleResourceRenameInPlaceConfig(name string, withPassword bool) string {
+	password := ""
+	if withPassword {
+		password = `password = "rename_password"`
+	}
+
+	return fmt.Sprintf(`
+resource "postgresql_role" "test" {
+  name            = %[1]q
+  rename_in_place = true
+  can_login       = true
+  %[2]s
+}
+`, name, password)
+}
+
+// testAccCheckRoleLogin returns a check that the role can log in to the test database with the password.
+func testAccCheckRoleLogin(name string, password string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		ctx := context.Background()
+
+		conn, err := pgx.Connect(ctx, testAccConnString(name, password))
+		if err != nil {
+			return fmt.Errorf("role %s is unable to log in: %w", name, err)
+		}
+
+		return conn.Close(ctx)
+	}
+}
+
+// expectRoleRenamePasswordWarning is a plan check that the role's ModifyPlan warns about the password being cleared
+// by the planned rename. Terraform's warnings aren't available to plan checks, so ModifyPlan is run again against the
+// test database with the prior and planned names.
+type expectRoleRenamePasswordWarning struct {
+	resourceAddress string
+	expectWarning   bool
+}
+
+func (e expectRoleRenamePasswordWarning) CheckPlan(ctx context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	for _, change := range req.Plan.ResourceChanges {
+		if change.Address != e.resourceAddress {
+			continue
+		}
+
+		before, _ := change.Change.Before.(map[string]any)
+		after, _ := change.Change.After.(map[string]any)
+		oid, _ := before["oid"].(float64)
+		priorName, _ := before["name"].(string)
+		plannedName, _ := after["name"].(string)
+		password, _ := after["password"].(string)
+
+		pool, err := pgxpool.New(ctx, testAccConnString(getEnv("DATABASE_USER", "terraform"), getEnv("DATABASE_PASSWORD", "not_a_real_password")))
+		if err != nil {
+			resp.Error = err
+			return
+		}
+		defer pool.Close()
+
+		r := &RoleResource{data: PostgresqlProviderData{DbPool: pool}}
+		schemaResp := &fwresource.SchemaResponse{}
+		r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+		roleValue := func(name string, password string) tftypes.Value {
+			values := map[string]tftypes.Value{
+				"oid":             tftypes.NewValue(tftypes.Number, oid),
+				"name":            tftypes.NewValue(tftypes.String, name),
+				"rename_in_place": tftypes.NewValue(tftypes.Bool, true),
+			}
+			if password != "" {
+				values["password"] = tftypes.NewValue(tftypes.String, password)
+			}
+			return testRoleValue(schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object), values)
+		}
+
+		modifyPlanResp := &fwresource.ModifyPlanResponse{}
+		r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: roleValue(plannedName, password)},
+			State:  tfsdk.State{Schema: schemaResp.Schema, Raw: roleValue(priorName, "")},
+			Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: roleValue(plannedName, password)},
+		}, modifyPlanResp)
+
+		if modifyPlanResp.Diagnostics.HasError() {
+			resp.Error = fmt.Errorf("unexpected errors: %v", modifyPlanResp.Diagnostics)
+			return
+		}
+
+		warned := false
+		for _, warning := range modifyPlanResp.Diagnostics.Warnings() {
+			warned = warned || warning.Summary() == "Role password will be cleared"
+		}
+		if warned != e.expectWarning {
+			resp.Error = fmt.Errorf("expected the password warning for %s to be %t, got %t", e.resourceAddress, e.expectWarning, warned)
+		}
+		return
+	}
+
+	resp.Error = fmt.Errorf("%s not found in plan", e.resourceAddress)
+}
+
+// testRoleValue returns a role object with the given attribute values, and the other attributes null.
+func testRoleValue(objectType tftypes.Object, values map[string]tftypes.Value) tftypes.Value {
+	objectValues := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		objectValues[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range values {
+		objectValues[name] = value
+	}
+
+	return tftypes.NewValue(objectType, objectValues)
+}
+
+func TestAccRoleResourceOIDMismatch(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		onOIDMismatch  string
+		expectedAction plancheck.ResourceActionType
+	}{
+		{
+			testName:       "Adopt",
+			onOIDMismatch:  "adopt",
+			expectedAction: plancheck.ResourceActionUpdate,
+		},
+		{
+			testName:       "Replace",
+			onOIDMismatch:  "replace",
+			expectedAction: plancheck.ResourceActionDestroyBeforeCreate,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			differentOid := statecheck.CompareValue(compare.ValuesDiffer())
+			roleName := "oid_mismatch_" + testCase.onOIDMismatch
+			config := providerConfigWithAttributes(fmt.Sprintf("on_oid_mismatch = %q", testCase.onOIDMismatch)) + fmt.Sprintf(`
+resource "postgresql_role" "test" {
+  name      = %q
+  can_login = true
+}
+`, roleName)
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					// Test role creation
+					{
+						Config: config,
+						ConfigStateChecks: []statecheck.StateCheck{
+							differentOid.AddStateValue("postgresql_role.test", tfjsonpath.New("oid")),
+						},
+					},
+					// Test the role is found again after it's dropped and re-created outside of Terraform
+					{
+						PreConfig: func() {
+							err := testAccExec(fmt.Sprintf("DROP ROLE %[1]s; CREATE ROLE %[1]s;", roleName))(nil)
+							if err != nil {
+								t.Fatal(err)
+							}
+						},
+						Config: config,
+						ConfigPlanChecks: resource.ConfigPlanChecks{
+							PreApply: []plancheck.PlanCheck{
+								plancheck.ExpectResourceAction("postgresql_role.test", testCase.expectedAction),
+							},
+						},
+						ConfigStateChecks: []statecheck.StateCheck{
+							differentOid.AddStateValue("postgresql_role.test", tfjsonpath.New("oid")),
+						},
+						Check: resource.TestCheckResourceAttr("postgresql_role.test", "can_login", "true"),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccRoleResourceDropBehavior(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// The schema created by the role has been reassigned rather than dropped with it
+		CheckDestroy: testAccExec("DROP SCHEMA owned_by_role;"),
+		Steps: []resource.TestStep{
+			// Test dropping a role which owns objects and holds privileges
+			{
+				Config: providerConfig() + `
+resource "postgresql_role" "test" {
+  name = "owner_role"
+
+  drop_behavior = {
+    reassign_owned_to = "terraform"
+    drop_owned        = true
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccExec("CREATE SCHEMA owned_by_role AUTHORIZATION owner_role;"),
+					testAccExec("GRANT CONNECT ON DATABASE terraform_test TO owner_role;"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRoleResourcePassword(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Write-only attributes were introduced in Terraform 1.11
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			// Test role creation with a password
+			{
+				Config: providerConfig() + `
+resource "postgresql_role" "test" {
+  name      = "password_role"
+  can_login = true
+  password  = "first_password"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role.test", "password", "first_password"),
+				),
+			},
+			// Test switching to a write-only password
+			{
+				Config: providerConfig() + `
+resource "postgresql_role" "test" {
+  name                = "password_role"
+  can_login           = true
+  password_wo         = "second_password"
+  password_wo_version = 1
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("postgresql_role.test", "password"),
+					resource.TestCheckNoResourceAttr("postgresql_role.test", "password_wo"),
+					resource.TestCheckResourceAttr("postgresql_role.test", "password_wo_version", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidUntilString(t *testing.T) {
+	expiry := time.Date(2035, time.June, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		testName       string
+		validUntil     pgtype.Timestamptz
+		priorValue     types.String
+		expectedOutput string
+	}{
+		{
+			testName:       "No expiry",
+			validUntil:     pgtype.Timestamptz{},
+			priorValue:     types.StringValue("infinity"),
+			expectedOutput: "infinity",
+		},
+		{
+			testName:       "Infinity",
+			validUntil:     pgtype.Timestamptz{Valid: true, InfinityModifier: pgtype.Infinity},
+			priorValue:     types.StringNull(),
+			expectedOutput: "infinity",
+		},
+		{
+			testName:       "Same instant in another time zone keeps the prior value",
+			validUntil:     pgtype.Timestamptz{Valid: true, Time: expiry},
+			priorValue:     types.StringValue("2035-06-01T12:00:00+02:00"),
+			expectedOutput: "2035-06-01T12:00:00+02:00",
+		},
+		{
+			testName:       "Changed outside of Terraform",
+			validUntil:     pgtype.Timestamptz{Valid: true, Time: expiry},
+			priorValue:     types.StringValue("2030-01-01T00:00:00Z"),
+			expectedOutput: "2035-06-01T10:00:00Z",
+		},
+		{
+			testName:       "Imported role",
+			validUntil:     pgtype.Timestamptz{Valid: true, Time: expiry.In(time.FixedZone("UTC-5", -5*60*60))},
+			priorValue:     types.StringNull(),
+			expectedOutput: "2035-06-01T10:00:00Z",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expectedOutput, validUntilString(testCase.validUntil, testCase.priorValue))
+		})
+	}
+}
+
+func TestAccRoleResourceParameters(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test role creation with parameters
+			{
+				Config: providerConfig() + testAccRoleResourceParametersConfig(`
+    statement_timeout = "1min"
+    search_path       = "\"$user\", public"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role.test", "parameters.%", "2"),
+					resource.TestCheckResourceAttr("postgresql_role.test", "parameters.statement_timeout", "1min"),
+					resource.TestCheckResourceAttr("postgresql_role.test", "database_parameters.terraform_test.work_mem", "64MB"),
+				),
+			},
+			// Test changing and removing parameters
+			{
+				Config: providerConfig() + testAccRoleResourceParametersConfig(`
+    statement_timeout = "30s"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role.test", "parameters.%", "1"),
+					resource.TestCheckResourceAttr("postgresql_role.test", "parameters.statement_timeout", "30s"),
+				),
+			},
+			// Test that the parameters of the role are imported
+			{
+				ResourceName:                         "postgresql_role.test",
+				ImportState:                          true,
+				ImportStateIdFunc:                    testAccRoleImportStateIdFunc("postgresql_role.test"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+				// An imported valid_until is in UTC, as there's no prior value to keep the time zone of.
+				ImportStateVerifyIgnore: []string{"valid_until"},
+				ImportStateCheck:        testAccRoleImportedValidUntilCheck("2035-06-01T12:00:00+02:00"),
+			},
+		},
+	})
+}
+
+func testAccRoleResourceParametersConfig(parameters string) string {
+	return fmt.Sprintf(`
+resource "postgresql_role" "test" {
+  name = "parameters_role"
+
+  parameters = {
+%s  }
+
+  database_parameters = {
+    terraform_test = {
+      work_mem = "64MB"
+    }
+  }
+}
+`, parameters)
+}
+
+func TestBuildRoleParameterStatements(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		database       string
+		prior          map[string]string
+		planned        map[string]string
+		expectedOutput []string
+	}{
+		{
+			testName: "New parameters",
+			planned:  map[string]string{"statement_timeout": "1min", "search_path": `"$user", public`},
+			expectedOutput: []string{
+				`ALTER ROLE "app" SET "search_path" = '$user', 'public';`,
+				`ALTER ROLE "app" SET "statement_timeout" = '1min';`,
+			},
+		},
+		{
+			testName: "Changed and removed parameters",
+			database: "analytics",
+			prior:    map[string]string{"statement_timeout": "1min", "work_mem": "64MB"},
+			planned:  map[string]string{"statement_timeout": "30s", "app.tenant": "acme's"},
+			expectedOutput: []string{
+				`ALTER ROLE "app" IN DATABASE "analytics" RESET "work_mem";`,
+				`ALTER ROLE "app" IN DATABASE "analytics" SET "app"."tenant" = 'acme''s';`,
+				`ALTER ROLE "app" IN DATABASE "analytics" SET "statement_timeout" = '30s';`,
+			},
+		},
+		{
+			testName:       "Unchanged parameters",
+			prior:          map[string]string{"statement_timeout": "1min"},
+			planned:        map[string]string{"statement_timeout": "1min"},
+			expectedOutput: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expectedOutput, buildRoleParameterStatements("app", testCase.database, testCase.prior, testCase.planned))
+		})
+	}
+}
+
+// recordingTx is a transaction recording the statements it executes, without a server.
+type recordingTx struct {
+	pgx.Tx
+	statements []string
+}
+
+func (tx *recordingTx) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	tx.statements = append(tx.statements, sql)
+	return pgconn.CommandTag{}, nil
+}
+
+func TestApplyAllRoleParameters(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		prior          RoleResourceModel
+		planned        RoleResourceModel
+		expectedOutput []string
+	}{
+		{
+			testName: "Empty prior of a created role",
+			prior:    RoleResourceModel{},
+			planned: RoleResourceModel{
+				Name:       types.StringValue("app"),
+				Parameters: types.MapValueMust(types.StringType, map[string]attr.Value{"statement_timeout": types.StringValue("1min")}),
+				DatabaseParameters: types.MapValueMust(types.MapType{ElemType: types.StringType}, map[string]attr.Value{
+					"analytics": types.MapValueMust(types.StringType, map[string]attr.Value{"work_mem": types.StringValue("64MB")}),
+				}),
+			},
+			expectedOutput: []string{
+				`ALTER ROLE "app" SET "statement_timeout" = '1min';`,
+				`ALTER ROLE "app" IN DATABASE "analytics" SET "work_mem" = '64MB';`,
+			},
+		},
+		{
+			testName:       "Empty prior and no planned parameters",
+			prior:          RoleResourceModel{},
+			planned:        RoleResourceModel{Name: types.StringValue("app")},
+			expectedOutput: nil,
+		},
+		{
+			testName: "Removed parameters",
+			prior: RoleResourceModel{
+				Name:               types.StringValue("app"),
+				Parameters:         types.MapValueMust(types.StringType, map[string]attr.Value{"statement_timeout": types.StringValue("1min")}),
+				DatabaseParameters: types.MapNull(types.MapType{ElemType: types.StringType}),
+			},
+			planned: RoleResourceModel{
+				Name:               types.StringValue("app"),
+				Parameters:         types.MapNull(types.StringType),
+				DatabaseParameters: types.MapNull(types.MapType{ElemType: types.StringType}),
+			},
+			expectedOutput: []string{`ALTER ROLE "app" RESET "statement_timeout";`},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			tx := &recordingTx{}
+
+			diags := applyAllRoleParameters(context.Background(), tx, testCase.prior, testCase.planned)
+			require.False(t, diags.HasError(), "%v", diags)
+			assert.Equal(t, testCase.expectedOutput, tx.statements)
+		})
+	}
+}
+
+func TestReconcileRoleParameters(t *testing.T) {
+	definitions := map[string]settingDefinition{
+		"statement_timeout": {vartype: "integer", unit: "ms"},
+		"datestyle":         {vartype: "string"},
+	}
+
+	testCases := []struct {
+		testName       string
+		prior          map[string]string
+		actual         map[string]string
+		expectedOutput map[string]string
+	}{
+		{
+			testName:       "Equivalent value keeps the prior value",
+			prior:          map[string]string{"statement_timeout": "1min"},
+			actual:         map[string]string{"statement_timeout": "60s"},
+			expectedOutput: map[string]string{"statement_timeout": "1min"},
+		},
+		{
+			testName:       "Changed value",
+			prior:          map[string]string{"statement_timeout": "1min"},
+			actual:         map[string]string{"statement_timeout": "2min"},
+			expectedOutput: map[string]string{"statement_timeout": "2min"},
+		},
+		{
+			testName:       "Name keeps the prior case",
+			prior:          map[string]string{"datestyle": "ISO, MDY"},
+			actual:         map[string]string{"DateStyle": "ISO, MDY"},
+			expectedOutput: map[string]string{"datestyle": "ISO, MDY"},
+		},
+		{
+			testName:       "Parameters set outside of Terraform",
+			prior:          map[string]string{},
+			actual:         map[string]string{"app.tenant": "acme"},
+			expectedOutput: map[string]string{"app.tenant": "acme"},
+		},
+		{
+			testName:       "Parameters removed outside of Terraform",
+			prior:          map[string]string{"statement_timeout": "1min"},
+			actual:         nil,
+			expectedOutput: map[string]string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expectedOutput, reconcileRoleParameters(testCase.prior, testCase.actual, definitions))
+		})
+	}
+}
+
+func TestBuildDropOwnedStatements(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		dropBehavior   RoleDropBehaviorModel
+		expectedOutput []string
+	}{
+		{
+			testName:       "No drop behavior",
+			dropBehavior:   RoleDropBehaviorModel{},
+			expectedOutput: nil,
+		},
+		{
+			testName:       "Reassign owned objects",
+			dropBehavior:   RoleDropBehaviorModel{ReassignOwnedTo: types.StringValue("Admin"), DropOwned: types.BoolNull()},
+			expectedOutput: []string{`REASSIGN OWNED BY "app" TO "Admin";`},
+		},
+		{
+			testName:       "Drop owned objects",
+			dropBehavior:   RoleDropBehaviorModel{ReassignOwnedTo: types.StringNull(), DropOwned: types.BoolValue(true)},
+			expectedOutput: []string{`DROP OWNED BY "app";`},
+		},
+		{
+			testName:       "Reassign owned objects, then revoke privileges",
+			dropBehavior:   RoleDropBehaviorModel{ReassignOwnedTo: types.StringValue("admin"), DropOwned: types.BoolValue(true)},
+			expectedOutput: []string{`REASSIGN OWNED BY "app" TO "admin";`, `DROP OWNED BY "app";`},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expectedOutput, buildDropOwnedStatements("app", testCase.dropBehavior))
+		})
+	}
+}
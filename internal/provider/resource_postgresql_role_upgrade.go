@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// roleSchemaVersion is the version of the postgresql_role schema. It's incremented whenever existing states can't be
+// read with the current schema as-is, along with an upgrader from the previous version in UpgradeState. The schemas
+// and models of the previous versions are kept frozen below, so that the states they describe can still be read.
+const roleSchemaVersion = 1
+
+// RoleResourceModelV0 is the model of the states of version 0. States written before `rename_in_place`,
+// `create_database` and `valid_until` were added lack them, so they're null.
+type RoleResourceModelV0 struct {
+	Oid                    types.Int64  `tfsdk:"oid"`
+	Name                   types.String `tfsdk:"name"`
+	RenameInPlace          types.Bool   `tfsdk:"rename_in_place"`
+	BypassRowLevelSecurity types.Bool   `tfsdk:"bypass_row_level_security"`
+	CanLogin               types.Bool   `tfsdk:"can_login"`
+	ConnectionLimit        types.Int32  `tfsdk:"connection_limit"`
+	CreateDatabase         types.Bool   `tfsdk:"create_database"`
+	CreateRole             types.Bool   `tfsdk:"create_role"`
+	Inherit                types.Bool   `tfsdk:"inherit"`
+	Replication            types.Bool   `tfsdk:"replication"`
+	Superuser              types.Bool   `tfsdk:"superuser"`
+	ValidUntil             types.String `tfsdk:"valid_until"`
+	Password               types.String `tfsdk:"password"`
+	PasswordWo             types.String `tfsdk:"password_wo"`
+	PasswordWoVersion      types.Int64  `tfsdk:"password_wo_version"`
+	Parameters             types.Map    `tfsdk:"parameters"`
+	DatabaseParameters     types.Map    `tfsdk:"database_parameters"`
+	DropBehavior           types.Object `tfsdk:"drop_behavior"`
+}
+
+// roleSchemaV0 returns the schema of the states of version 0. Only the attribute types matter to read the states, so
+// the descriptions, defaults, validators and plan modifiers are left out.
+func roleSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"oid":                       schema.Int64Attribute{Computed: true},
+			"name":                      schema.StringAttribute{Required: true},
+			"rename_in_place":           schema.BoolAttribute{Optional: true, Computed: true},
+			"bypass_row_level_security": schema.BoolAttribute{Optional: true, Computed: true},
+			"can_login":                 schema.BoolAttribute{Optional: true, Computed: true},
+			"connection_limit":          schema.Int32Attribute{Optional: true, Computed: true},
+			"create_database":           schema.BoolAttribute{Optional: true, Computed: true},
+			"create_role":               schema.BoolAttribute{Optional: true, Computed: true},
+			"inherit":                   schema.BoolAttribute{Optional: true, Computed: true},
+			"replication":               schema.BoolAttribute{Optional: true, Computed: true},
+			"superuser":                 schema.BoolAttribute{Optional: true, Computed: true},
+			"valid_until":               schema.StringAttribute{Optional: true, Computed: true},
+			"password":                  schema.StringAttribute{Optional: true, Sensitive: true},
+			"password_wo":               schema.StringAttribute{Optional: true, Sensitive: true, WriteOnly: true},
+			"password_wo_version":       schema.Int64Attribute{Optional: true},
+			"parameters":                schema.MapAttribute{Optional: true, ElementType: types.StringType},
+			"database_parameters":       schema.MapAttribute{Optional: true, ElementType: types.MapType{ElemType: types.StringType}},
+			"drop_behavior": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"reassign_owned_to": schema.StringAttribute{Optional: true},
+					"drop_owned":        schema.BoolAttribute{Optional: true},
+				},
+			},
+		},
+	}
+}
+
+// UpgradeState returns the upgraders of the states of every previous schema version. Each of them upgrades its states
+// straight to the current version.
+func (r *RoleResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   roleSchemaV0(),
+			StateUpgrader: upgradeRoleStateV0,
+		},
+	}
+}
+
+// upgradeRoleStateV0 upgrades a state of version 0. The attributes added after the first states were written are set
+// to their defaults when they're null, so that upgraded roles aren't planned to be updated to the same values.
+func upgradeRoleStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var priorData RoleResourceModelV0
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &priorData)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgradedData := RoleResourceModel{
+		Oid:                    priorData.Oid,
+		Name:                   priorData.Name,
+		RenameInPlace:          priorData.RenameInPlace,
+		BypassRowLevelSecurity: priorData.BypassRowLevelSecurity,
+		CanLogin:               priorData.CanLogin,
+		ConnectionLimit:        priorData.ConnectionLimit,
+		CreateDatabase:         priorData.CreateDatabase,
+		CreateRole:             priorData.CreateRole,
+		Inherit:                priorData.Inherit,
+		Replication:            priorData.Replication,
+		Superuser:              priorData.Superuser,
+		ValidUntil:             priorData.ValidUntil,
+		Password:               priorData.Password,
+		PasswordWo:             priorData.PasswordWo,
+		PasswordWoVersion:      priorData.PasswordWoVersion,
+		Parameters:             priorData.Parameters,
+		DatabaseParameters:     priorData.DatabaseParameters,
+		DropBehavior:           priorData.DropBehavior,
+	}
+
+	if upgradedData.RenameInPlace.IsNull() {
+		upgradedData.RenameInPlace = types.BoolValue(false)
+	}
+	if upgradedData.CreateDatabase.IsNull() {
+		upgradedData.CreateDatabase = types.BoolValue(false)
+	}
+	if upgradedData.ValidUntil.IsNull() {
+		upgradedData.ValidUntil = types.StringValue("infinity")
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &upgradedData)...)
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleUpgradeStateVersions(t *testing.T) {
+	t.Parallel()
+
+	roleResource := NewRoleResource().(*RoleResource)
+
+	var schemaResp resource.SchemaResponse
+	roleResource.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	require.Equal(t, int64(roleSchemaVersion), schemaResp.Schema.Version)
+
+	// Every previous version needs an upgrader, as states of any of them may still be around.
+	upgraders := roleResource.UpgradeState(context.Background())
+	for version := int64(0); version < roleSchemaVersion; version++ {
+		assert.Contains(t, upgraders, version, "missing state upgrader of version %d", version)
+	}
+	assert.Len(t, upgraders, roleSchemaVersion)
+}
+
+// TestRoleUpgradeState upgrades the states of testdata/role_state, named after the version they were written with,
+// and compares them to the states expected after the upgrade.
+func TestRoleUpgradeState(t *testing.T) {
+	testCases := []struct {
+		testName     string
+		version      int64
+		stateFile    string
+		upgradedFile string
+	}{
+		{
+			testName:     "Version 0 written before the attributes with defaults were added",
+			version:      0,
+			stateFile:    "v0_baseline.json",
+			upgradedFile: "v0_baseline_upgraded.json",
+		},
+		{
+			testName:     "Version 0 with every attribute",
+			version:      0,
+			stateFile:    "v0.json",
+			upgradedFile: "v0_upgraded.json",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			server, err := providerserver.NewProtocol6WithError(New("test")())()
+			require.NoError(t, err)
+
+			schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+			require.NoError(t, err)
+			require.Empty(t, schemaResp.Diagnostics)
+			stateType := schemaResp.ResourceSchemas["postgresql_role"].ValueType()
+
+			state, err := os.ReadFile(filepath.Join("testdata", "role_state", testCase.stateFile))
+			require.NoError(t, err)
+
+			resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+				TypeName: "postgresql_role",
+				Version:  testCase.version,
+				RawState: &tfprotov6.RawState{JSON: state},
+			})
+			require.NoError(t, err)
+			require.Empty(t, resp.Diagnostics)
+
+			upgradedState, err := resp.UpgradedState.Unmarshal(stateType)
+			require.NoError(t, err)
+
+			expectedState, err := os.ReadFile(filepath.Join("testdata", "role_state", testCase.upgradedFile))
+			require.NoError(t, err)
+
+			expectedUpgradedState, err := (&tfprotov6.RawState{JSON: expectedState}).Unmarshal(stateType)
+			require.NoError(t, err)
+
+			diffs, err := expectedUpgradedState.Diff(upgradedState)
+			require.NoError(t, err)
+			assert.Empty(t, diffs)
+		})
+	}
+}
@@ -0,0 +1,452 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ktham/terraform-provider-postgresql/internal/postgresql/pgsql"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SchemaResource{}
+var _ resource.ResourceWithModifyPlan = &SchemaResource{}
+var _ resource.ResourceWithImportState = &SchemaResource{}
+var _ resource.ResourceWithIdentity = &SchemaResource{}
+
+func NewSchemaResource() resource.Resource {
+	return &SchemaResource{}
+}
+
+type SchemaResource struct {
+	data PostgresqlProviderData
+}
+
+type SchemaResourceModel struct {
+	Oid         types.Int64  `tfsdk:"oid"`
+	Name        types.String `tfsdk:"name"`
+	Database    types.String `tfsdk:"database"`
+	Owner       types.String `tfsdk:"owner"`
+	DropCascade types.Bool   `tfsdk:"drop_cascade"`
+}
+
+// SchemaResourceIdentityModel is the identity of a schema. Schema OIDs are only unique within a database, so schemas
+// are identified by name.
+type SchemaResourceIdentityModel struct {
+	Database types.String `tfsdk:"database"`
+	Name     types.String `tfsdk:"name"`
+}
+
+func (r *SchemaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schema"
+	// The name of the identity changes when the schema is renamed.
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+func (r *SchemaResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"database": identityschema.StringAttribute{
+				Description:       "The database of the Postgresql schema.",
+				RequiredForImport: true,
+			},
+			"name": identityschema.StringAttribute{
+				Description:       "The name of the Postgresql schema.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *SchemaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Postgresql Schema",
+
+		Attributes: map[string]schema.Attribute{
+			"oid": schema.Int64Attribute{
+				Description: "The object ID of the Postgresql schema.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the Postgresql schema.",
+				Required:    true,
+			},
+			"database": schema.StringAttribute{
+				Description: "The database in which the schema is created. Defaults to the database the provider is connected to.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Description: "The role that owns the schema. Defaults to the role used by the provider to connect.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"drop_cascade": schema.BoolAttribute{
+				Description: "Determines whether the objects contained in the schema are dropped along with it (`CASCADE`). " +
+					"When false (the default), dropping a schema that isn't empty fails (`RESTRICT`).",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *SchemaResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(PostgresqlProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected PostgresqlProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.data = data
+}
+
+func (r *SchemaResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.data.ConfigUnknown {
+		planUnknownValues(ctx, req, resp)
+	}
+}
+
+func (r *SchemaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var dataFromPlan SchemaResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataFromPlan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !isKnown(dataFromPlan.Database) {
+		dataFromPlan.Database = types.StringValue(r.data.DatabaseName())
+	}
+
+	pool, err := r.data.DatabasePool(ctx, dataFromPlan.Database.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("DB Connection Error", fmt.Sprintf("Unable to connect to database '%s', got error: %s", dataFromPlan.Database.ValueString(), err))
+		return
+	}
+
+	txn, err := pool.Begin(ctx)
+
+	if err != nil {
+		resp.Diagnostics.AddError("DB Connection Error", fmt.Sprintf("Unable to start a new transaction, got error: %s", err))
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			err := txn.Rollback(ctx)
+			if err != nil {
+				resp.Diagnostics.AddError("Transaction Rollback Error", fmt.Sprintf("Unable to rollback transaction, got error: %s", err))
+			}
+		}
+	}()
+
+	createSchemaSql := fmt.Sprintf("CREATE SCHEMA %s", pgsql.Identifier(dataFromPlan.Name.ValueString()))
+	if isKnown(dataFromPlan.Owner) {
+		createSchemaSql += fmt.Sprintf(" AUTHORIZATION %s", pgsql.Identifier(dataFromPlan.Owner.ValueString()))
+	}
+	createSchemaSql += ";"
+
+	tflog.Info(ctx, createSchemaSql)
+
+	if _, err = txn.Exec(ctx, createSchemaSql); err != nil {
+		resp.Diagnostics.AddError("DB schema creation error", fmt.Sprintf("Error executing query '%s', got error: %s", createSchemaSql, err))
+		return
+	}
+
+	var schemaOID uint32
+	var owner string
+	selectOidQuery := "SELECT oid, pg_get_userbyid(nspowner) FROM pg_namespace WHERE nspname = $1"
+
+	err = txn.QueryRow(ctx, selectOidQuery, dataFromPlan.Name.ValueString()).Scan(&schemaOID, &owner)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to retrieve schema OID", fmt.Sprintf("Error retrieving schema OID with query `%s`, got error: %s", selectOidQuery, err))
+		return
+	}
+
+	dataFromPlan.Oid = types.Int64Value(int64(schemaOID))
+	dataFromPlan.Owner = types.StringValue(owner)
+
+	err = txn.Commit(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("DB transaction error", fmt.Sprintf("Error committing DB transaction, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Successfully created Postgresql Schema: %s", dataFromPlan.Name.ValueString()))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromPlan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, SchemaResourceIdentityModel{Database: dataFromPlan.Database, Name: dataFromPlan.Name})...)
+}
+
+func (r *SchemaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.data.ConfigUnknown {
+		return
+	}
+
+	var dataFromState SchemaResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &dataFromState)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pool, err := r.data.DatabasePool(ctx, dataFromState.Database.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("DB Connection Error", fmt.Sprintf("Unable to connect to database '%s', got error: %s", dataFromState.Database.ValueString(), err))
+		return
+	}
+
+	err = readSchema(ctx, pool, &dataFromState)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			resp.Diagnostics.AddWarning("No results returned", fmt.Sprintf("The Postgres schema couldn't be found. schema: %s", dataFromState.Name.ValueString()))
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("SQL query to read schema encountered an unexpected error, please share this with the developer, error: %s", err))
+		}
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, SchemaResourceIdentityModel{Database: dataFromState.Database, Name: dataFromState.Name})...)
+}
+
+// readSchema refreshes the attributes of the model that are stored in pg_namespace from the schema identified by the
+// model's OID, using the pool of the schema's database. It returns pgx.ErrNoRows if the schema no longer exists.
+func readSchema(ctx context.Context, pool *pgxpool.Pool, data *SchemaResourceModel) error {
+	schemaSql := `
+SELECT
+    nspname,
+    pg_get_userbyid(nspowner)
+FROM
+    pg_namespace
+WHERE
+    oid = $1;`
+
+	var name string
+	var owner string
+
+	if err := pool.QueryRow(ctx, schemaSql, data.Oid.ValueInt64()).Scan(&name, &owner); err != nil {
+		return err
+	}
+
+	data.Name = types.StringValue(name)
+	data.Owner = types.StringValue(owner)
+
+	return nil
+}
+
+func (r *SchemaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var dataFromPlan SchemaResourceModel
+	var dataFromState SchemaResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataFromPlan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &dataFromState)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pool, err := r.data.DatabasePool(ctx, dataFromPlan.Database.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("DB Connection Error", fmt.Sprintf("Unable to connect to database '%s', got error: %s", dataFromPlan.Database.ValueString(), err))
+		return
+	}
+
+	txn, err := pool.Begin(ctx)
+
+	if err != nil {
+		resp.Diagnostics.AddError("DB Connection Error", fmt.Sprintf("Unable to start a new transaction, got error: %s", err))
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			err := txn.Rollback(ctx)
+			if err != nil {
+				resp.Diagnostics.AddError("Transaction Rollback Error", fmt.Sprintf("Unable to rollback transaction, got error: %s", err))
+			}
+		}
+	}()
+
+	schemaName := pgsql.Identifier(dataFromPlan.Name.ValueString())
+
+	var statements []string
+
+	if !dataFromPlan.Name.Equal(dataFromState.Name) {
+		statements = append(statements, fmt.Sprintf("ALTER SCHEMA %s RENAME TO %s;", pgsql.Identifier(dataFromState.Name.ValueString()), schemaName))
+	}
+	if isKnown(dataFromPlan.Owner) && !dataFromPlan.Owner.Equal(dataFromState.Owner) {
+		statements = append(statements, fmt.Sprintf("ALTER SCHEMA %s OWNER TO %s;", schemaName, pgsql.Identifier(dataFromPlan.Owner.ValueString())))
+	}
+
+	for _, alterSchemaSql := range statements {
+		tflog.Info(ctx, alterSchemaSql)
+
+		if _, err = txn.Exec(ctx, alterSchemaSql); err != nil {
+			resp.Diagnostics.AddError("DB schema update error", fmt.Sprintf("Error executing query '%s', got error: %s", alterSchemaSql, err))
+			return
+		}
+	}
+
+	var owner string
+	if err = txn.QueryRow(ctx, "SELECT pg_get_userbyid(nspowner) FROM pg_namespace WHERE oid = $1", dataFromPlan.Oid.ValueInt64()).Scan(&owner); err != nil {
+		resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("Unable to read back the schema owner, got error: %s", err))
+		return
+	}
+
+	dataFromPlan.Owner = types.StringValue(owner)
+
+	if err = txn.Commit(ctx); err != nil {
+		resp.Diagnostics.AddError("DB transaction error", fmt.Sprintf("Error committing DB transaction, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Successfully altered Postgresql Schema: %s", dataFromPlan.Name.ValueString()))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataFromPlan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, SchemaResourceIdentityModel{Database: dataFromPlan.Database, Name: dataFromPlan.Name})...)
+}
+
+func (r *SchemaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SchemaResourceModel
+
+	// Read Terraform prior state data into the model...
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pool, err := r.data.DatabasePool(ctx, data.Database.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("DB Connection Error", fmt.Sprintf("Unable to connect to database '%s', got error: %s", data.Database.ValueString(), err))
+		return
+	}
+
+	dropBehavior := "RESTRICT"
+	if data.DropCascade.ValueBool() {
+		dropBehavior = "CASCADE"
+	}
+
+	dropSchemaSql := fmt.Sprintf("DROP SCHEMA %s %s;", pgsql.Identifier(data.Name.ValueString()), dropBehavior)
+
+	tflog.Info(ctx, dropSchemaSql)
+
+	if _, err = pool.Exec(ctx, dropSchemaSql); err != nil {
+		resp.Diagnostics.AddError("DB schema deletion error", fmt.Sprintf("Error executing query '%s', got error: %s", dropSchemaSql, err))
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Successfully dropped Postgresql Schema: %s", data.Name.ValueString()))
+}
+
+// ImportState imports a schema using an ID of the form `database.schema`, or the identity of an import block. The ID is
+// split on the first dot, so the schema name may itself contain dots but the database name may not.
+func (r *SchemaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var databaseName, schemaName string
+
+	if req.ID != "" {
+		var found bool
+		databaseName, schemaName, found = strings.Cut(req.ID, ".")
+
+		if !found || databaseName == "" || schemaName == "" {
+			resp.Diagnostics.AddError(
+				"Invalid import ID",
+				fmt.Sprintf("Expected an import ID of the form `database.schema`, got: %s", req.ID),
+			)
+			return
+		}
+	} else {
+		var identity SchemaResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		databaseName = identity.Database.ValueString()
+		schemaName = identity.Name.ValueString()
+
+		if databaseName == "" || schemaName == "" {
+			resp.Diagnostics.AddError("Invalid import identity", "Expected the database and the name of the schema to be set.")
+			return
+		}
+	}
+
+	pool, err := r.data.DatabasePool(ctx, databaseName)
+	if err != nil {
+		resp.Diagnostics.AddError("DB Connection Error", fmt.Sprintf("Unable to connect to database '%s', got error: %s", databaseName, err))
+		return
+	}
+
+	var schemaOID uint32
+
+	err = pool.QueryRow(ctx, "SELECT oid FROM pg_namespace WHERE nspname = $1", schemaName).Scan(&schemaOID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			resp.Diagnostics.AddError("Schema not found", fmt.Sprintf("No schema named '%s' exists in database '%s'.", schemaName, databaseName))
+		} else {
+			resp.Diagnostics.AddError("DB Query Error", fmt.Sprintf("Unable to look up the schema to import, got error: %s", err))
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(seedImportedSchema(ctx, resp.State.SetAttribute, databaseName, schemaOID, schemaName)...)
+}
+
+// seedImportedSchema sets the attributes of an imported schema which Read doesn't refresh with setAttribute.
+func seedImportedSchema(ctx context.Context, setAttribute func(context.Context, path.Path, any) diag.Diagnostics, databaseName string, schemaOID uint32, schemaName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(setAttribute(ctx, path.Root("oid"), int64(schemaOID))...)
+	diags.Append(setAttribute(ctx, path.Root("name"), schemaName)...)
+	diags.Append(setAttribute(ctx, path.Root("database"), databaseName)...)
+	diags.Append(setAttribute(ctx, path.Root("drop_cascade"), false)...)
+
+	return diags
+}
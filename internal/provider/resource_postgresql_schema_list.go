@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &SchemaListResource{}
+var _ list.ListResourceWithConfigure = &SchemaListResource{}
+
+func NewSchemaListResource() list.ListResource {
+	return &SchemaListResource{}
+}
+
+// SchemaListResource lists the schemas of a database.
+type SchemaListResource struct {
+	schema SchemaResource
+}
+
+type SchemaListResourceModel struct {
+	Database             types.String `tfsdk:"database"`
+	NamePattern          types.String `tfsdk:"name_pattern"`
+	IncludeSystemSchemas types.Bool   `tfsdk:"include_system_schemas"`
+}
+
+func (r *SchemaListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	r.schema.Metadata(ctx, req, resp)
+}
+
+func (r *SchemaListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the Postgresql schemas of a database.",
+
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "The database whose schemas are listed. Defaults to the database the provider is connected to.",
+				Optional:    true,
+			},
+			"name_pattern": schema.StringAttribute{
+				Description: "A `LIKE` pattern the names of the listed schemas match, such as `app\\_%`.",
+				Optional:    true,
+			},
+			"include_system_schemas": schema.BoolAttribute{
+				Description: "Determines whether the system schemas, `information_schema` and the schemas whose names start with " +
+					"`pg_`, are listed. Defaults to false.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+func (r *SchemaListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.schema.Configure(ctx, req, resp)
+}
+
+func (r *SchemaListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var diags diag.Diagnostics
+
+	if r.schema.data.ConfigUnknown {
+		diags.AddError(
+			"Unknown provider configuration",
+			"Schemas can't be listed while the provider configuration has values which are only known during apply.",
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var filters SchemaListResourceModel
+
+	diags.Append(req.Config.Get(ctx, &filters)...)
+
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	databaseName := filters.Database.ValueString()
+	if filters.Database.IsNull() {
+		databaseName = r.schema.data.DatabaseName()
+	}
+
+	pool, err := r.schema.data.DatabasePool(ctx, databaseName)
+	if err != nil {
+		diags.AddError("DB Connection Error", fmt.Sprintf("Unable to connect to database '%s', got error: %s", databaseName, err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	query, args := buildSchemaListQuery(filters, req.Limit)
+
+	schemas, err := queryListedObjects(ctx, pool, query, args)
+	if err != nil {
+		diags.AddError("DB Query Error", fmt.Sprintf("Unable to list the schemas of database '%s', got error: %s", databaseName, err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, schema := range schemas {
+			result := req.NewListResult(ctx)
+			result.DisplayName = databaseName + "." + schema.name
+
+			identity := SchemaResourceIdentityModel{Database: types.StringValue(databaseName), Name: types.StringValue(schema.name)}
+			result.Diagnostics.Append(result.Identity.Set(ctx, identity)...)
+
+			if req.IncludeResource {
+				result.Diagnostics.Append(readListedSchema(ctx, pool, result.Resource, databaseName, schema)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// readListedSchema sets resource to the state of a listed schema of databaseName, as if it was imported.
+func readListedSchema(ctx context.Context, pool *pgxpool.Pool, resource *tfsdk.Resource, databaseName string, schema listedObject) diag.Diagnostics {
+	diags := seedImportedSchema(ctx, resource.SetAttribute, databaseName, schema.oid, schema.name)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	var data SchemaResourceModel
+	diags.Append(resource.Get(ctx, &data)...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := readSchema(ctx, pool, &data); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			diags.AddError("Schema not found", fmt.Sprintf("Schema '%s' was dropped while it was listed.", schema.name))
+		} else {
+			diags.AddError("DB Query Error", fmt.Sprintf("Unable to read schema '%s', got error: %s", schema.name, err))
+		}
+		return diags
+	}
+
+	diags.Append(resource.Set(ctx, &data)...)
+
+	return diags
+}
+
+// buildSchemaListQuery returns the query listing the OIDs and names of the schemas matching filters, along with the
+// query's arguments.
+func buildSchemaListQuery(filters SchemaListResourceModel, limit int64) (string, []any) {
+	var query listQuery
+
+	if !filters.NamePattern.IsNull() {
+		query.whereArg("nspname LIKE", filters.NamePattern.ValueString())
+	}
+	if !filters.IncludeSystemSchemas.ValueBool() {
+		query.where(`nspname NOT LIKE 'pg\_%'`)
+		query.where(`nspname <> 'information_schema'`)
+	}
+
+	return query.build("SELECT oid, nspname FROM pg_namespace", "nspname", limit)
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck/queryfilter"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccSchemaListResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// List resources were introduced in Terraform 1.14
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig() + `
+resource "postgresql_schema" "test" {
+  name = "list_schema"
+}
+`,
+			},
+			// Test listing the schemas of the provider's database
+			{
+				Query: true,
+				Config: providerConfig() + `
+list "postgresql_schema" "test" {
+  provider         = postgresql
+  include_resource = true
+
+  config {
+    name_pattern = "list\\_%"
+  }
+}
+`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("postgresql_schema.test", 1),
+					querycheck.ExpectIdentity("postgresql_schema.test", map[string]knownvalue.Check{
+						"database": knownvalue.StringExact("terraform_test"),
+						"name":     knownvalue.StringExact("list_schema"),
+					}),
+					querycheck.ExpectResourceKnownValues("postgresql_schema.test", queryfilter.ByDisplayName(knownvalue.StringExact("terraform_test.list_schema")), []querycheck.KnownValueCheck{
+						{Path: tfjsonpath.New("owner"), KnownValue: knownvalue.NotNull()},
+						{Path: tfjsonpath.New("drop_cascade"), KnownValue: knownvalue.Bool(false)},
+					}),
+				},
+			},
+			// Test listing the system schemas of another database
+			{
+				Query: true,
+				Config: providerConfig() + `
+list "postgresql_schema" "test" {
+  provider = postgresql
+
+  config {
+    database               = "postgres"
+    name_pattern           = "pg\\_catalog"
+    include_system_schemas = true
+  }
+}
+`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectIdentity("postgresql_schema.test", map[string]knownvalue.Check{
+						"database": knownvalue.StringExact("postgres"),
+						"name":     knownvalue.StringExact("pg_catalog"),
+					}),
+				},
+			},
+		},
+	})
+}
+
+func TestBuildSchemaListQuery(t *testing.T) {
+	testCases := []struct {
+		testName      string
+		filters       SchemaListResourceModel
+		expectedQuery string
+		expectedArgs  []any
+	}{
+		{
+			testName: "No filters",
+			filters: SchemaListResourceModel{
+				Database:             types.StringNull(),
+				NamePattern:          types.StringNull(),
+				IncludeSystemSchemas: types.BoolNull(),
+			},
+			expectedQuery: `SELECT oid, nspname FROM pg_namespace WHERE nspname NOT LIKE 'pg\_%' AND nspname <> 'information_schema' ORDER BY nspname;`,
+			expectedArgs:  []any{},
+		},
+		{
+			testName: "Every filter",
+			filters: SchemaListResourceModel{
+				Database:             types.StringValue("app"),
+				NamePattern:          types.StringValue("app\\_%"),
+				IncludeSystemSchemas: types.BoolValue(true),
+			},
+			expectedQuery: `SELECT oid, nspname FROM pg_namespace WHERE nspname LIKE $1 ORDER BY nspname;`,
+			expectedArgs:  []any{"app\\_%"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			query, args := buildSchemaListQuery(testCase.filters, 0)
+			assert.Equal(t, testCase.expectedQuery, query)
+			assert.Equal(t, testCase.expectedArgs, args)
+		})
+	}
+}
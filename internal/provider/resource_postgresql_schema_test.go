@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSchemaResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Test schema creation
+			{
+				Config: providerConfig() + testAccSchemaResourceConfig("schema1", "schema_owner1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_schema.test", "name", "schema1"),
+					resource.TestCheckResourceAttr("postgresql_schema.test", "database", "terraform_test"),
+					resource.TestCheckResourceAttr("postgresql_schema.test", "owner", "schema_owner1"),
+					resource.TestCheckResourceAttr("postgresql_schema.test", "drop_cascade", "true"),
+					resource.TestCheckResourceAttrSet("postgresql_schema.test", "oid"),
+				),
+			},
+			// Test schema owner update
+			{
+				Config: providerConfig() + testAccSchemaResourceConfig("schema1", "schema_owner2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_schema.test", "name", "schema1"),
+					resource.TestCheckResourceAttr("postgresql_schema.test", "owner", "schema_owner2"),
+				),
+			},
+			// Test schema re-name
+			{
+				Config: providerConfig() + testAccSchemaResourceConfig("Schema 2", "schema_owner2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_schema.test", "name", "Schema 2"),
+					resource.TestCheckResourceAttr("postgresql_schema.test", "owner", "schema_owner2"),
+				),
+			},
+			// Test schema import
+			{
+				ResourceName:            "postgresql_schema.test",
+				ImportState:             true,
+				ImportStateId:           "terraform_test.Schema 2",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"drop_cascade"},
+			},
+		},
+	})
+}
+
+func TestAccSchemaResourceIdentity(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Resource identity was introduced in Terraform 1.12
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig() + testAccSchemaResourceIdentityConfig("identity_schema1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("postgresql_schema.test", map[string]knownvalue.Check{
+						"database": knownvalue.StringExact("terraform_test"),
+						"name":     knownvalue.StringExact("identity_schema1"),
+					}),
+				},
+			},
+			// Test that the identity follows a re-name
+			{
+				Config: providerConfig() + testAccSchemaResourceIdentityConfig("identity_schema2"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValue("postgresql_schema.test", tfjsonpath.New("name"), knownvalue.StringExact("identity_schema2")),
+				},
+			},
+			// Test schema import with an import block identifying the schema with its identity
+			{
+				ResourceName:    "postgresql_schema.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func testAccSchemaResourceIdentityConfig(name string) string {
+	return fmt.Sprintf(`
+resource "postgresql_schema" "test" {
+  name = %q
+}
+`, name)
+}
+
+func testAccSchemaResourceConfig(name string, owner string) string {
+	return fmt.Sprintf(`
+resource "postgresql_role" "owner1" {
+  name = "schema_owner1"
+}
+
+resource "postgresql_role" "owner2" {
+  name = "schema_owner2"
+}
+
+resource "postgresql_schema" "test" {
+  name         = %[1]q
+  owner        = %[2]q
+  drop_cascade = true
+
+  depends_on = [postgresql_role.owner1, postgresql_role.owner2]
+}
+`, name, owner)
+}